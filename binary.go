@@ -0,0 +1,148 @@
+package ripoff
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Postgres' hex format for bytea columns, ex: \x0102ff
+func formatBytea(value []byte) string {
+	return `\x` + hex.EncodeToString(value)
+}
+
+// Converts the result of another valueFunc to bytes, decoding bytea hex if needed.
+func parseBytea(value string) ([]byte, error) {
+	if strings.HasPrefix(value, `\x`) {
+		return hex.DecodeString(value[2:])
+	}
+	return []byte(value), nil
+}
+
+func randomBytes(r *rand.Rand, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("bytes requires a length, ex: bytes(seed, 16)")
+	}
+	length, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", err
+	}
+	if length < 0 {
+		return "", fmt.Errorf("bytes length cannot be negative, got %d", length)
+	}
+	value := make([]byte, length)
+	_, err = r.Read(value)
+	if err != nil {
+		return "", err
+	}
+	return formatBytea(value), nil
+}
+
+func randomColor(r *rand.Rand) color.RGBA {
+	return color.RGBA{R: uint8(r.Intn(256)), G: uint8(r.Intn(256)), B: uint8(r.Intn(256)), A: 255}
+}
+
+// Generates a PNG image. Args are in the format: width, height, [style], where style is identicon or gradient.
+func randomImage(r *rand.Rand, args []string) (string, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return "", fmt.Errorf("image requires a width and height, and optionally a style, ex: image(seed, 64, 64, gradient)")
+	}
+	width, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", err
+	}
+	height, err := strconv.Atoi(args[1])
+	if err != nil {
+		return "", err
+	}
+	if width <= 0 || height <= 0 || width > 4096 || height > 4096 {
+		return "", fmt.Errorf("image width and height must be between 1 and 4096, got %dx%d", width, height)
+	}
+	style := "identicon"
+	if len(args) == 3 {
+		style = args[2]
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	switch style {
+	case "identicon":
+		// A 5x5 grid that's mirrored horizontally, like GitHub's default avatars.
+		background := color.RGBA{R: 240, G: 240, B: 240, A: 255}
+		foreground := randomColor(r)
+		filled := [5][5]bool{}
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 3; x++ {
+				filled[y][x] = r.Intn(2) == 1
+				filled[y][4-x] = filled[y][x]
+			}
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if filled[y*5/height][x*5/width] {
+					img.SetRGBA(x, y, foreground)
+				} else {
+					img.SetRGBA(x, y, background)
+				}
+			}
+		}
+	case "gradient":
+		// A diagonal gradient between two colors.
+		from, to := randomColor(r), randomColor(r)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				progress := float64(x+y) / float64(max(width+height-2, 1))
+				img.SetRGBA(x, y, color.RGBA{
+					R: uint8(float64(from.R) + (float64(to.R)-float64(from.R))*progress),
+					G: uint8(float64(from.G) + (float64(to.G)-float64(from.G))*progress),
+					B: uint8(float64(from.B) + (float64(to.B)-float64(from.B))*progress),
+					A: 255,
+				})
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown image style %s, expected identicon or gradient", style)
+	}
+
+	buf := &bytes.Buffer{}
+	err = png.Encode(buf, img)
+	if err != nil {
+		return "", err
+	}
+	return formatBytea(buf.Bytes()), nil
+}
+
+// Loads a file's contents, relative to the directory of the ripoff file or template that defined the row.
+func loadFile(dir string, path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("file requires a path, ex: file(fixtures/avatar.png)")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return formatBytea(contents), nil
+}
+
+// Encodes a value, which may be the bytea result of another valueFunc, as base64 or hex text.
+func encodeValue(encoding string, value string) (string, error) {
+	decoded, err := parseBytea(value)
+	if err != nil {
+		return "", err
+	}
+	if encoding == "base64" {
+		return base64.StdEncoding.EncodeToString(decoded), nil
+	}
+	return hex.EncodeToString(decoded), nil
+}
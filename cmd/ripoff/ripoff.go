@@ -73,8 +73,17 @@ func main() {
 	softPtr := flag.Bool("s", false, "do not commit generated queries")
 	maxConcurrencyPtr := flag.Int("c", ripoff.DEFAULT_MAX_CONCURRENCY, "maximum number of rows to generate queries for at one time. defaults at 1000")
 	unsafePluginPtr := flag.Bool("u", false, "execute new plugin commands without prompting. only for use in CI or trusted environments")
+	listPtr := flag.Bool("l", false, "list all gofakeit valueFuncs and exit")
+	seedPtr := flag.String("seed", "", "mixed into every valueFunc seed to generate an alternate dataset. overrides the seed in ripoff files")
 	flag.Parse()
 
+	if *listPtr {
+		for _, signature := range ripoff.FakerValueFuncs() {
+			fmt.Println(signature)
+		}
+		return
+	}
+
 	if *verbosePtr {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
 	}
@@ -124,7 +133,15 @@ func main() {
 	}
 
 	rootDirectory := path.Clean(flag.Arg(0))
-	totalRipoff, err := ripoff.RipoffFromDirectory(rootDirectory, enums)
+	options := ripoff.RipoffOptions{Seed: *seedPtr, Context: ctx}
+	if !*unsafePluginPtr {
+		// Templates that call plugin valueFuncs with fake start plugins while loading.
+		options.ConfirmPlugins = func(plugins map[string]ripoff.RipoffPlugin) error {
+			confirmPluginsSafe(plugins)
+			return nil
+		}
+	}
+	totalRipoff, err := ripoff.RipoffFromDirectoryWithOptions(rootDirectory, enums, options)
 	if err != nil {
 		slog.Error("Could not load ripoff", errAttr(err))
 		os.Exit(1)
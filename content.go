@@ -0,0 +1,208 @@
+package ripoff
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// Options that can be passed to markdown and html after the seed, ex: markdown(seed, sections=5, image=true).
+var contentOptions = map[string]string{
+	// Number of sections, each with a heading.
+	"sections": "3",
+	// Number of paragraphs in each section.
+	"paragraphs": "2",
+	// Number of sentences in each paragraph.
+	"sentences": "4",
+	"lists":     "true",
+	"code":      "true",
+	"links":     "true",
+	"image":     "false",
+}
+
+// Code blocks are picked from these languages, where %s is replaced with a random word.
+var contentCodeSamples = map[string]string{
+	"go":   "func %s() error {\n\treturn nil\n}",
+	"js":   "function %s() {\n  return null;\n}",
+	"sql":  "SELECT * FROM %s;",
+	"bash": "echo \"%s\"",
+}
+
+type contentBlock struct {
+	// One of: heading, paragraph, list, code, image
+	kind  string
+	text  string
+	items []string
+	// The URL of a link inside a paragraph, or the source of an image.
+	url      string
+	linkText string
+	language string
+}
+
+// Parses key=value options, using defaults for anything that isn't set.
+func parseContentOptions(kind string, args []string) (map[string]string, error) {
+	options := map[string]string{}
+	for key, defaultValue := range contentOptions {
+		options[key] = defaultValue
+	}
+	for _, arg := range args {
+		key, optionValue, isOption := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		_, isKnownOption := contentOptions[key]
+		if !isOption || !isKnownOption {
+			knownOptions := []string{}
+			for knownOption := range contentOptions {
+				knownOptions = append(knownOptions, knownOption)
+			}
+			slices.Sort(knownOptions)
+			return nil, fmt.Errorf("unknown %s option %s, expected key=value where key is one of: %s", kind, arg, strings.Join(knownOptions, ", "))
+		}
+		options[key] = strings.TrimSpace(optionValue)
+	}
+	return options, nil
+}
+
+func contentTitle(faker *gofakeit.Faker, wordCount int) string {
+	return strings.TrimSuffix(faker.LoremIpsumSentence(wordCount), ".")
+}
+
+// Generates the structure of a document, which can then be rendered as markdown or HTML.
+func randomContent(kind string, faker *gofakeit.Faker, args []string) ([]contentBlock, error) {
+	options, err := parseContentOptions(kind, args)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, key := range []string{"sections", "paragraphs", "sentences"} {
+		counts[key], err = strconv.Atoi(options[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s option %s must be a number: %w", kind, key, err)
+		}
+		if counts[key] < 0 {
+			return nil, fmt.Errorf("%s option %s cannot be negative", kind, key)
+		}
+	}
+	flags := map[string]bool{}
+	for _, key := range []string{"lists", "code", "links", "image"} {
+		flags[key], err = strconv.ParseBool(options[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s option %s must be true or false: %w", kind, key, err)
+		}
+	}
+
+	blocks := []contentBlock{{kind: "heading", text: contentTitle(faker, 5)}}
+	if flags["image"] {
+		blocks = append(blocks, contentBlock{
+			kind: "image",
+			text: contentTitle(faker, 3),
+			url:  fmt.Sprintf("https://picsum.photos/seed/%s/800/400", faker.LoremIpsumWord()),
+		})
+	}
+	languages := []string{}
+	for language := range contentCodeSamples {
+		languages = append(languages, language)
+	}
+	slices.Sort(languages)
+	for section := 0; section < counts["sections"]; section++ {
+		blocks = append(blocks, contentBlock{kind: "heading", text: contentTitle(faker, 3)})
+		for paragraph := 0; paragraph < counts["paragraphs"]; paragraph++ {
+			block := contentBlock{kind: "paragraph", text: faker.LoremIpsumParagraph(1, counts["sentences"], 10, "")}
+			// The first paragraph of each section ends with a link.
+			if flags["links"] && paragraph == 0 {
+				block.linkText = faker.LoremIpsumWord()
+				block.url = faker.URL()
+			}
+			blocks = append(blocks, block)
+		}
+		// Sections alternate between lists and code blocks.
+		if flags["lists"] && (section%2 == 0 || !flags["code"]) {
+			items := []string{}
+			for i := 0; i < 3+faker.IntN(3); i++ {
+				items = append(items, faker.LoremIpsumSentence(4+faker.IntN(4)))
+			}
+			blocks = append(blocks, contentBlock{kind: "list", items: items})
+		} else if flags["code"] {
+			language := languages[faker.IntN(len(languages))]
+			blocks = append(blocks, contentBlock{
+				kind:     "code",
+				language: language,
+				text:     fmt.Sprintf(contentCodeSamples[language], faker.LoremIpsumWord()),
+			})
+		}
+	}
+	return blocks, nil
+}
+
+func renderMarkdown(blocks []contentBlock) string {
+	rendered := []string{}
+	for i, block := range blocks {
+		switch block.kind {
+		case "heading":
+			level := "##"
+			if i == 0 {
+				level = "#"
+			}
+			rendered = append(rendered, level+" "+block.text)
+		case "paragraph":
+			if block.url != "" {
+				rendered = append(rendered, fmt.Sprintf("%s See [%s](%s).", block.text, block.linkText, block.url))
+			} else {
+				rendered = append(rendered, block.text)
+			}
+		case "list":
+			rendered = append(rendered, "- "+strings.Join(block.items, "\n- "))
+		case "code":
+			rendered = append(rendered, fmt.Sprintf("```%s\n%s\n```", block.language, block.text))
+		case "image":
+			rendered = append(rendered, fmt.Sprintf("![%s](%s)", block.text, block.url))
+		}
+	}
+	return strings.Join(rendered, "\n\n") + "\n"
+}
+
+func renderHTML(blocks []contentBlock) string {
+	rendered := []string{}
+	for i, block := range blocks {
+		switch block.kind {
+		case "heading":
+			level := 2
+			if i == 0 {
+				level = 1
+			}
+			rendered = append(rendered, fmt.Sprintf("<h%d>%s</h%d>", level, html.EscapeString(block.text), level))
+		case "paragraph":
+			text := html.EscapeString(block.text)
+			if block.url != "" {
+				text += fmt.Sprintf(` See <a href="%s">%s</a>.`, html.EscapeString(block.url), html.EscapeString(block.linkText))
+			}
+			rendered = append(rendered, "<p>"+text+"</p>")
+		case "list":
+			items := []string{}
+			for _, item := range block.items {
+				items = append(items, "<li>"+html.EscapeString(item)+"</li>")
+			}
+			rendered = append(rendered, "<ul>\n"+strings.Join(items, "\n")+"\n</ul>")
+		case "code":
+			rendered = append(rendered, fmt.Sprintf(`<pre><code class="language-%s">%s</code></pre>`, block.language, html.EscapeString(block.text)))
+		case "image":
+			rendered = append(rendered, fmt.Sprintf(`<img src="%s" alt="%s">`, html.EscapeString(block.url), html.EscapeString(block.text)))
+		}
+	}
+	return strings.Join(rendered, "\n") + "\n"
+}
+
+// Generates a markdown or HTML document. The same arguments generate the same document in either format.
+func randomDocument(kind string, faker *gofakeit.Faker, args []string) (string, error) {
+	blocks, err := randomContent(kind, faker, args)
+	if err != nil {
+		return "", err
+	}
+	if kind == "html" {
+		return renderHTML(blocks), nil
+	}
+	return renderMarkdown(blocks), nil
+}
@@ -0,0 +1,212 @@
+package ripoff
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts accepted for absolute times, tried in order.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+	time.TimeOnly,
+	"15:04",
+}
+
+// Default output formats for the random time valueFuncs.
+var defaultTimeFormats = map[string]string{
+	"timestamp": time.RFC3339,
+	"date":      time.DateOnly,
+	"time":      time.TimeOnly,
+}
+
+// Named formats, so users don't have to remember Go's reference time.
+var namedTimeFormats = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"datetime": time.DateTime,
+	"date":     time.DateOnly,
+	"time":     time.TimeOnly,
+}
+
+var relativeTimeRegex = regexp.MustCompile(`^([+-]?\d+)(s|m|h|d|w|mo|y)$`)
+var offsetRangeRegex = regexp.MustCompile(`^(\d+)(?:-(\d+))?\s*([a-zA-Z]+)$`)
+
+var offsetUnits = map[string]time.Duration{
+	"s":       time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+	"m":       time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+	"d":       24 * time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"w":       7 * 24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+}
+
+// Parses a time in any of the supported layouts, using loc when the time has no offset.
+func parseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		parsed, err := time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse time: %s", value)
+}
+
+// Parses one end of a time range, which can be "now", relative to now (ex: -90d), or an absolute time.
+func parseTimeBound(bound string, now time.Time, loc *time.Location) (time.Time, error) {
+	bound = strings.TrimSpace(bound)
+	if bound == "now" {
+		return now, nil
+	}
+	relativeMatches := relativeTimeRegex.FindStringSubmatch(bound)
+	if len(relativeMatches) != 3 {
+		return parseTime(bound, loc)
+	}
+	amount, err := strconv.Atoi(relativeMatches[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch relativeMatches[2] {
+	case "s":
+		return now.Add(time.Duration(amount) * time.Second), nil
+	case "m":
+		return now.Add(time.Duration(amount) * time.Minute), nil
+	case "h":
+		return now.Add(time.Duration(amount) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, amount), nil
+	case "w":
+		return now.AddDate(0, 0, amount*7), nil
+	case "mo":
+		return now.AddDate(0, amount, 0), nil
+	default:
+		return now.AddDate(amount, 0, 0), nil
+	}
+}
+
+// Formats a time with a named format, "unix", "unixMilli", or a Go layout.
+func formatTime(t time.Time, format string) string {
+	switch format {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixMilli":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	layout, isNamed := namedTimeFormats[format]
+	if isNamed {
+		return t.Format(layout)
+	}
+	return t.Format(format)
+}
+
+// Generates a random timestamp, date, or time of day in the half-open range [start, end).
+// Args are in the format: start, end, [timezone], [format]
+func randomTime(kind string, r *randv2.Rand, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("%s requires a start and end, ex: %s(seed, 2023-01-01, 2024-01-01)", kind, kind)
+	}
+	loc := time.UTC
+	if len(args) > 2 && args[2] != "" {
+		var err error
+		loc, err = time.LoadLocation(args[2])
+		if err != nil {
+			return "", err
+		}
+	}
+	format := defaultTimeFormats[kind]
+	if len(args) > 3 && args[3] != "" {
+		format = args[3]
+	}
+
+	now := time.Now().In(loc)
+	start, err := parseTimeBound(args[0], now, loc)
+	if err != nil {
+		return "", err
+	}
+	end, err := parseTimeBound(args[1], now, loc)
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case "date":
+		// Only whole days are generated, so the time of day is ignored.
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+		days := int(end.Sub(start).Round(time.Hour).Hours() / 24)
+		if days <= 0 {
+			return "", fmt.Errorf("%s range is empty: %s to %s", kind, args[0], args[1])
+		}
+		return formatTime(start.AddDate(0, 0, r.IntN(days)), format), nil
+	case "time":
+		// Only the time of day matters, so bounds like "now" and "09:00" can be mixed.
+		start = time.Date(0, 1, 1, start.Hour(), start.Minute(), start.Second(), 0, loc)
+		end = time.Date(0, 1, 1, end.Hour(), end.Minute(), end.Second(), 0, loc)
+	}
+
+	seconds := end.Unix() - start.Unix()
+	if seconds <= 0 {
+		return "", fmt.Errorf("%s range is empty: %s to %s", kind, args[0], args[1])
+	}
+	return formatTime(start.Truncate(time.Second).Add(time.Duration(r.Int64N(seconds))*time.Second), format), nil
+}
+
+// Generates a timestamp offset after or before a referenced time, within a range like "1-72 hours".
+// Args are in the format: offset, [format]
+func relativeTime(kind string, r *randv2.Rand, referencedValue string, args []string) (string, error) {
+	referenced, err := parseTime(referencedValue, time.UTC)
+	if err != nil {
+		return "", err
+	}
+	format := time.RFC3339
+	if len(args) > 1 && args[1] != "" {
+		format = args[1]
+	}
+	offsetMatches := offsetRangeRegex.FindStringSubmatch(strings.TrimSpace(args[0]))
+	if len(offsetMatches) != 4 {
+		return "", fmt.Errorf("cannot parse %s offset %s, expected a format like 1-72 hours", kind, args[0])
+	}
+	unit, hasUnit := offsetUnits[offsetMatches[3]]
+	if !hasUnit {
+		return "", fmt.Errorf("unknown %s offset unit %s", kind, offsetMatches[3])
+	}
+	min, err := strconv.Atoi(offsetMatches[1])
+	if err != nil {
+		return "", err
+	}
+	offset := time.Duration(min) * unit
+	if offsetMatches[2] != "" {
+		max, err := strconv.Atoi(offsetMatches[2])
+		if err != nil {
+			return "", err
+		}
+		if max <= min {
+			return "", fmt.Errorf("%s offset range is empty: %s", kind, args[0])
+		}
+		// Pick a random second within the half-open range, so offsets don't all align to the unit.
+		offset += time.Duration(r.Int64N(int64((time.Duration(max-min)*unit)/time.Second))) * time.Second
+	}
+	if kind == "before" {
+		offset = -offset
+	}
+	return formatTime(referenced.Add(offset), format), nil
+}
@@ -2,10 +2,10 @@ package ripoff
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math/rand"
 	randv2 "math/rand/v2"
 	"regexp"
@@ -37,7 +37,24 @@ func RunRipoff(ctx context.Context, tx pgx.Tx, totalRipoff RipoffFile, maxConcur
 		return err
 	}
 
-	queries, err := buildQueriesForRipoff(maxConcurrency, manager, primaryKeys, totalRipoff)
+	enums, err := GetEnumValues(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	uniqueColumns, err := getUniqueColumns(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	vc := newValueFuncContext(manager, totalRipoff, enums)
+
+	err = vc.resolveUniqueCollisions(uniqueColumns)
+	if err != nil {
+		return err
+	}
+
+	queries, err := buildQueriesForRipoff(maxConcurrency, vc, primaryKeys, totalRipoff)
 	if err != nil {
 		return err
 	}
@@ -122,64 +139,245 @@ func GetEnumValues(ctx context.Context, tx pgx.Tx) (EnumValuesResult, error) {
 var valueFuncRegex = regexp.MustCompile(`([a-zA-Z0-9]+)\((.*)\)$`)
 var referenceRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+:[a-zA-Z0-9]+\(`)
 var naturalDatePlaceholderRegex = regexp.MustCompile(`r\d+-\d+`)
+var columnReferenceRegex = regexp.MustCompile(`^(.+\))\.([a-zA-Z0-9_]+)$`)
+
+// References between rows are resolved recursively, so this guards against cycles.
+const maxReferenceDepth = 100
+
+// State shared by all valueFunc calls in a single run of ripoff.
+type valueFuncContext struct {
+	manager *PluginManager
+	rows    map[string]Row
+	enums   EnumValuesResult
+	// Sorted row ids for each table, so that picking a random row is deterministic.
+	rowIdsByTable map[string][]string
+	locale        string
+	// Row level options, like ~locale, for the row currently being prepared.
+	rowOptions Row
+	// Salts for values in unique columns that collided with another row, keyed by <rowId>.<column>.
+	uniqueSalts  map[string]int
+	dictionaries map[string]Dictionary
+	// The ripoff directory, which file() paths are relative to for rows that aren't in rowDirs.
+	dir string
+	// The directory of the file that defined each row.
+	rowDirs map[string]string
+	// Password hashes are slow to generate, and many rows tend to share the same password.
+	passwordHashes *sync.Map
+	// Positions of seq() calls in their counters, keyed by <rowId>.<column>.
+	sequences map[string]int
+	// Mixed into every hash, so that a different seed produces a different dataset.
+	seed  string
+	depth int
+}
+
+// Hashes a valueFunc's arguments (and the global seed, if set) for use as a random seed.
+func (vc valueFuncContext) hash(value string) [32]byte {
+	return seedHash(vc.seed, value)
+}
+
+// Applies row level options, like ~locale, to valueFuncs called for this row.
+func (vc valueFuncContext) forRow(row Row) *valueFuncContext {
+	vc.rowOptions = row
+	return &vc
+}
+
+func (vc valueFuncContext) rowLocale() string {
+	rowLocale, hasRowLocale := vc.rowOptions["~locale"].(string)
+	if hasRowLocale {
+		return rowLocale
+	}
+	return vc.locale
+}
+
+// Returns the directory that file() paths in a row are relative to.
+func (vc valueFuncContext) rowDir(rowId string) string {
+	rowDir, hasRowDir := vc.rowDirs[rowId]
+	if hasRowDir {
+		return rowDir
+	}
+	return vc.dir
+}
+
+// Derives a seed for valueFuncs called without one, ex: email(), from the row id (or ~seed) and column.
+func (vc valueFuncContext) implicitSeed(rowId string, column string) (string, error) {
+	if column == "" {
+		return "", fmt.Errorf("valueFuncs without a seed can only be used in columns, not in row ids like %s", rowId)
+	}
+	rowSeed, hasRowSeed := vc.rowOptions["~seed"]
+	if hasRowSeed && rowSeed != nil {
+		return fmt.Sprintf("%v.%s", rowSeed, column), nil
+	}
+	return rowId + "." + column, nil
+}
+
+func newValueFuncContext(manager *PluginManager, totalRipoff RipoffFile, enums EnumValuesResult) *valueFuncContext {
+	rowIdsByTable := map[string][]string{}
+	for rowId := range totalRipoff.Rows {
+		table, _, hasTable := strings.Cut(rowId, ":")
+		if hasTable {
+			rowIdsByTable[table] = append(rowIdsByTable[table], rowId)
+		}
+	}
+	for _, rowIds := range rowIdsByTable {
+		slices.Sort(rowIds)
+	}
+	return &valueFuncContext{
+		manager:        manager,
+		rows:           totalRipoff.Rows,
+		enums:          enums,
+		rowIdsByTable:  rowIdsByTable,
+		locale:         totalRipoff.Locale,
+		seed:           totalRipoff.Seed,
+		uniqueSalts:    map[string]int{},
+		sequences:      buildSequences(totalRipoff.Rows),
+		dictionaries:   totalRipoff.Dictionaries,
+		passwordHashes: &sync.Map{},
+		dir:            totalRipoff.Dir,
+		rowDirs:        totalRipoff.RowDirs,
+	}
+}
+
+// Resolves a reference in the format <rowId>.<column> to the final value of that column.
+func (vc valueFuncContext) resolveColumnReference(reference string) (string, string, error) {
+	referenceMatches := columnReferenceRegex.FindStringSubmatch(reference)
+	if len(referenceMatches) != 3 {
+		return "", "", fmt.Errorf("invalid column reference %s, expected format is table:valueFunc(seed).column", reference)
+	}
+	rowId := referenceMatches[1]
+	column := referenceMatches[2]
+	row, hasRow := vc.rows[rowId]
+	if !hasRow {
+		return "", rowId, fmt.Errorf("column reference %s points to a row that does not exist", reference)
+	}
+	valueRaw, hasColumn := row[column]
+	if !hasColumn || valueRaw == nil {
+		return "", rowId, fmt.Errorf("column reference %s points to a column that is not set", reference)
+	}
+	if vc.depth >= maxReferenceDepth {
+		return "", rowId, fmt.Errorf("column reference %s is nested too deeply, there may be a cycle", reference)
+	}
+	vc.depth++
+	value, _, err := prepareValue(vc.forRow(row), rowId, column, fmt.Sprint(valueRaw))
+	return value, rowId, err
+}
+
+// valueFuncs provided by ripoff, which take precedence over gofakeit methods with the same name.
+// Keep in sync with the switch in prepareValue.
+var builtinValueFuncs = []string{
+	"uuidv7", "uuid", "ulid", "ksuid", "nanoid", "snowflake", "uuidv5",
+	"int", "literal", "naturalDate", "timestamp", "date", "time",
+	"oneOf", "weighted", "bool", "float", "seq", "fromList",
+	"bcrypt", "argon2id", "sha256", "hmac", "apiToken",
+	"latlng", "pointWKT", "polygonWKT", "geohash",
+	"markdown", "html", "bytes", "image", "file", "base64", "hex",
+	"regex", "pattern", "normal", "lognormal", "exponential", "zipf",
+	"enum", "pick", "after", "before",
+}
 
-func prepareValue(manager *PluginManager, rawValue string) (string, error) {
+// Returns the prepared value, and any rows that the value depends on.
+// The column is used to derive a seed when none is provided, and is empty when preparing row ids.
+func prepareValue(vc *valueFuncContext, rowId string, column string, rawValue string) (string, []string, error) {
+	rawValue, fieldPath := splitFieldPath(rawValue)
 	valueFuncMatches := valueFuncRegex.FindStringSubmatch(rawValue)
 	if len(valueFuncMatches) != 3 {
-		return rawValue, nil
+		return rawValue, nil, nil
 	}
 	methodName := valueFuncMatches[1]
 	value := valueFuncMatches[2]
 	valueParts := strings.Split(strings.ReplaceAll(valueFuncMatches[2], ", ", ","), ",")
+	// Literals are allowed to be empty, and sequences default to a counter per column.
+	// Row ids and references to them, ex: users:uuid(), keep an empty seed so that they still match each other.
+	// Plugins are passed their arguments as-is.
+	if value == "" && methodName != "literal" && methodName != "seq" && !referenceRegex.MatchString(rawValue) && !vc.manager.Supports(methodName) {
+		implicitSeed, err := vc.implicitSeed(rowId, column)
+		if err != nil {
+			return "", nil, err
+		}
+		value = implicitSeed
+		valueParts = []string{implicitSeed}
+	}
+	return prepareValueParts(vc, rowId, column, methodName, value, valueParts, fieldPath)
+}
 
-	if manager.Supports(methodName) {
-		return manager.Call(methodName, valueParts...)
+// Prepares a valueFunc call that was already parsed. The value is the full argument string, which seeds random
+// generators, and valueParts are the individual arguments, starting with the seed.
+func prepareValueParts(vc *valueFuncContext, rowId string, column string, methodName string, value string, valueParts []string, fieldPath string) (string, []string, error) {
+	if vc.manager.Supports(methodName) {
+		value, err := vc.manager.Call(methodName, valueParts...)
+		return value, nil, err
+	}
+
+	// Re-generate values that collided in a unique column.
+	seedValue := value
+	salt := vc.uniqueSalts[rowId+"."+column]
+	if salt > 0 {
+		seedValue = fmt.Sprintf("%s\x00%d", value, salt)
 	}
 
 	// Create a new random seed based on a sha256 hash of the value.
-	h := sha256.New()
-	h.Write([]byte(value))
-	hashBytes := h.Sum(nil)
+	hashBytes := vc.hash(seedValue)
 	// Note: for backwards compatability we don't want to migrate fully to v2
-	randSeed := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hashBytes))))
-	randv2Seed := randv2.New(randv2.NewChaCha8(sha256.Sum256([]byte(value))))
+	randSeed := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hashBytes[:]))))
+	randv2Seed := randv2.New(randv2.NewChaCha8(hashBytes))
 
 	// Check for methods provided by ripoff.
 	switch methodName {
 	case "uuidv7":
-		randomId, err := NewV7FromReader(randSeed)
+		var randomId uuid.UUID
+		var err error
+		if len(valueParts) > 1 {
+			randomId, err = uuidv7InWindow(randSeed, valueParts[1:])
+		} else {
+			randomId, err = NewV7FromReader(randSeed)
+		}
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
-		return randomId.String(), nil
+		return randomId.String(), nil, nil
 	case "uuid":
 		randomId, err := uuid.NewRandomFromReader(randSeed)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
-		return randomId.String(), nil
+		return randomId.String(), nil, nil
+	case "ulid":
+		randomId, err := newULID(randSeed)
+		return randomId, nil, err
+	case "ksuid":
+		randomId, err := newKSUID(randSeed)
+		return randomId, nil, err
+	case "nanoid":
+		randomId, err := newNanoid(randSeed, valueParts[1:])
+		return randomId, nil, err
+	case "snowflake":
+		randomId, err := newSnowflake(randSeed, valueParts[1:])
+		return randomId, nil, err
+	case "uuidv5":
+		// v5 UUIDs are already deterministic, so the first argument is a namespace instead of a seed.
+		namespacedId, err := newUUIDv5(valueParts[0], rawArgument(value))
+		return namespacedId, nil, err
 	case "int":
 		if len(valueParts) == 3 {
 			min, err := strconv.Atoi(valueParts[1])
 			if err != nil {
-				return "", err
+				return "", nil, err
 			}
 			max, err := strconv.Atoi(valueParts[2])
 			if err != nil {
-				return "", err
+				return "", nil, err
 			}
-			return strconv.Itoa(randv2Seed.IntN(max-min) + min), nil
+			return strconv.Itoa(randv2Seed.IntN(max-min) + min), nil, nil
 		} else if len(valueParts) == 2 {
 			max, err := strconv.Atoi(valueParts[1])
 			if err != nil {
-				return "", err
+				return "", nil, err
 			}
-			return strconv.Itoa(randv2Seed.IntN(max)), nil
+			return strconv.Itoa(randv2Seed.IntN(max)), nil, nil
 		} else {
-			return strconv.Itoa(randSeed.Int()), nil
+			return strconv.Itoa(randSeed.Int()), nil, nil
 		}
 	case "literal":
-		return value, nil
+		return value, nil, nil
 	case "naturalDate":
 		// Uses seeding/replacement syntax
 		if len(valueParts) == 2 {
@@ -197,20 +395,182 @@ func prepareValue(manager *PluginManager, rawValue string) (string, error) {
 			})
 		}
 		parsed, err := naturaldate.Parse(value, time.Now().UTC())
-		return parsed.Format(time.RFC3339), err
+		return parsed.Format(time.RFC3339), nil, err
+	case "timestamp", "date", "time":
+		randomValue, err := randomTime(methodName, randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "oneOf":
+		randomValue, err := randomOneOf(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "weighted":
+		randomValue, err := randomWeighted(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "bool":
+		randomValue, err := randomBool(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "float":
+		randomValue, err := randomFloat(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "seq":
+		position, hasPosition := vc.sequences[rowId+"."+column]
+		if !hasPosition {
+			return "", nil, fmt.Errorf("seq can only be used as the value of a column, got seq(%s)", value)
+		}
+		sequenceResult, err := sequenceValue(position, valueParts[1:])
+		return sequenceResult, nil, err
+	case "fromList":
+		randomValue, err := randomFromList(randv2Seed, vc.dictionaries, valueParts[1:])
+		return randomValue, nil, err
+	case "bcrypt", "argon2id":
+		cacheKey := methodName + string(hashBytes[:])
+		cachedHash, isCached := vc.passwordHashes.Load(cacheKey)
+		if isCached {
+			return cachedHash.(string), nil, nil
+		}
+		password, args, err := passwordArgs(value, valueParts)
+		if err != nil {
+			return "", nil, err
+		}
+		var passwordHash string
+		if methodName == "bcrypt" {
+			if len(args) > 1 {
+				return "", nil, fmt.Errorf("bcrypt accepts a password and cost, ex: bcrypt(hunter2, 12). Quote passwords that contain commas")
+			}
+			cost := 10
+			if len(args) == 1 {
+				cost, err = strconv.Atoi(args[0])
+				if err != nil {
+					return "", nil, err
+				}
+			}
+			passwordHash, err = bcryptHash(randSeed, password, cost)
+		} else {
+			passwordHash, err = argon2idHash(randSeed, password, args)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		vc.passwordHashes.Store(cacheKey, passwordHash)
+		return passwordHash, nil, nil
+	case "sha256":
+		return sha256Hex(value), nil, nil
+	case "hmac":
+		return hmacHex(valueParts[0], rawArgument(value)), nil, nil
+	case "apiToken":
+		randomToken, err := apiToken(randSeed, valueParts[1:])
+		return randomToken, nil, err
+	case "latlng":
+		randomValue, err := randomLatLngValue(randv2Seed, valueParts[1:], fieldPath)
+		return randomValue, nil, err
+	case "pointWKT":
+		randomValue, err := randomPointWKT(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "polygonWKT":
+		randomValue, err := randomPolygonWKT(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "geohash":
+		randomValue, err := randomGeohash(randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "markdown", "html":
+		document, err := randomDocument(methodName, gofakeit.NewFaker(randSeed, true), valueParts[1:])
+		return document, nil, err
+	case "bytes":
+		randomValue, err := randomBytes(randSeed, valueParts[1:])
+		return randomValue, nil, err
+	case "image":
+		randomValue, err := randomImage(randSeed, valueParts[1:])
+		return randomValue, nil, err
+	case "file":
+		// Like uuidv5, the argument is not a seed.
+		fileValue, err := loadFile(vc.rowDir(rowId), strings.TrimSpace(value))
+		return fileValue, nil, err
+	case "base64", "hex":
+		// The argument can be another valueFunc, ex: base64(image(seed, 64, 64))
+		innerValue, dependencies, err := prepareValue(vc, rowId, column, value)
+		if err != nil {
+			return "", nil, err
+		}
+		encodedValue, err := encodeValue(methodName, innerValue)
+		return encodedValue, dependencies, err
+	case "regex":
+		randomValue, err := randomRegex(gofakeit.NewFaker(randSeed, true), rawArgument(value))
+		return randomValue, nil, err
+	case "pattern":
+		randomValue, err := randomPattern(randv2Seed, rawArgument(value))
+		return randomValue, nil, err
+	case "normal", "lognormal", "exponential", "zipf":
+		randomValue, err := randomFromDistribution(methodName, randv2Seed, valueParts[1:])
+		return randomValue, nil, err
+	case "enum":
+		if len(valueParts) != 2 {
+			return "", nil, fmt.Errorf("enum requires a seed and enum name, ex: enum(seed, user_role)")
+		}
+		enumValues, hasEnum := vc.enums[valueParts[1]]
+		if !hasEnum {
+			return "", nil, fmt.Errorf("enum %s does not exist", valueParts[1])
+		}
+		return enumValues[randv2Seed.IntN(len(enumValues))], nil, nil
+	case "pick":
+		if len(valueParts) != 2 {
+			return "", nil, fmt.Errorf("pick requires a seed and table name, ex: pick(seed, users)")
+		}
+		candidates := vc.rowIdsByTable[valueParts[1]]
+		// Rows can't pick themselves, which would be a confusing self-reference.
+		selfIndex, pickingSelf := slices.BinarySearch(candidates, rowId)
+		candidateCount := len(candidates)
+		if pickingSelf {
+			candidateCount--
+		}
+		if candidateCount <= 0 {
+			return "", nil, fmt.Errorf("pick could not find any rows for table %s", valueParts[1])
+		}
+		pickedIndex := randv2Seed.IntN(candidateCount)
+		if pickingSelf && pickedIndex >= selfIndex {
+			pickedIndex++
+		}
+		pickedRowId := candidates[pickedIndex]
+		pickedValue, _, err := prepareValue(vc.forRow(vc.rows[pickedRowId]), pickedRowId, "", pickedRowId)
+		return pickedValue, []string{pickedRowId}, err
+	case "after", "before":
+		if len(valueParts) < 2 {
+			return "", nil, fmt.Errorf("%s requires a column reference and offset, ex: %s(posts:uuid(p1).created_at, 1-72 hours)", methodName, methodName)
+		}
+		referencedValue, referencedRowId, err := vc.resolveColumnReference(valueParts[0])
+		if err != nil {
+			return "", nil, err
+		}
+		// Seed with the current row as well, so rows that share a reference don't share an offset.
+		rowRandSeed := randv2.New(randv2.NewChaCha8(vc.hash(rowId + seedValue)))
+		relativeValue, err := relativeTime(methodName, rowRandSeed, referencedValue, valueParts[1:])
+		return relativeValue, []string{referencedRowId}, err
 	}
 
 	// Assume the user meant to call a gofakeit.Faker method.
 	faker := gofakeit.NewFaker(randSeed, true)
+	locale, err := getLocale(vc.rowLocale())
+	if err != nil {
+		return "", nil, err
+	}
+	if slices.Contains(structuredValueFuncs, methodName) {
+		structuredResult, err := structuredValue(methodName, faker, locale, fieldPath)
+		return structuredResult, nil, err
+	}
+	if locale != nil {
+		localizedResult, isLocalized := locale.callFakerMethod(methodName, faker)
+		if isLocalized {
+			return localizedResult, nil, nil
+		}
+	}
 	fakerResult, err := callFakerMethod(methodName, faker, valueParts...)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return fakerResult, nil
+	return fakerResult, nil, nil
 }
 
-func buildQueryForRow(manager *PluginManager, primaryKeys PrimaryKeysResult, rowId string, row Row) (string, []string, error) {
+func buildQueryForRow(vc *valueFuncContext, primaryKeys PrimaryKeysResult, rowId string, row Row) (string, []string, error) {
+	vc = vc.forRow(row)
 	dependencyResult := []string{}
 	parts := strings.Split(rowId, ":")
 	if len(parts) < 2 {
@@ -235,6 +595,8 @@ func buildQueryForRow(manager *PluginManager, primaryKeys PrimaryKeysResult, row
 			column := primaryKeysForTable[0]
 			_, hasPrimaryColumn := row[column]
 			if !hasPrimaryColumn {
+				// Other rows may be reading this row concurrently, so avoid mutating it.
+				row = maps.Clone(row)
 				row[column] = rowId
 			}
 		}
@@ -245,6 +607,10 @@ func buildQueryForRow(manager *PluginManager, primaryKeys PrimaryKeysResult, row
 		if column == "~conflict" {
 			continue
 		}
+		// Row level options are applied to the valueFuncContext.
+		if column == "~locale" || column == "~seed" {
+			continue
+		}
 		// Explicit dependencies, for foreign keys to non-primary keys.
 		if column == "~dependencies" {
 			dependencies := []string{}
@@ -283,10 +649,16 @@ func buildQueryForRow(manager *PluginManager, primaryKeys PrimaryKeysResult, row
 			}
 
 			columns = append(columns, pq.QuoteIdentifier(column))
-			valuePrepared, err := prepareValue(manager, value)
+			valuePrepared, valueDependencies, err := prepareValue(vc, rowId, column, value)
 			if err != nil {
 				return "", dependencyResult, err
 			}
+			for _, dependency := range valueDependencies {
+				if dependency != rowId {
+					dependencyResult = append(dependencyResult, dependency)
+				}
+			}
+			dependencyResult = slices.Compact(dependencyResult)
 			// Assume this column is the primary key.
 			if rowId == value && onConflictColumn == "" {
 				onConflictColumn = pq.QuoteIdentifier(column)
@@ -315,7 +687,7 @@ func buildQueryForRow(manager *PluginManager, primaryKeys PrimaryKeysResult, row
 }
 
 // Returns a sorted array of queries to run based on a given ripoff file.
-func buildQueriesForRipoff(maxConcurrency int, manager *PluginManager, primaryKeys PrimaryKeysResult, totalRipoff RipoffFile) ([]string, error) {
+func buildQueriesForRipoff(maxConcurrency int, vc *valueFuncContext, primaryKeys PrimaryKeysResult, totalRipoff RipoffFile) ([]string, error) {
 	dependencyGraph := map[string][]string{}
 	queries := map[string]string{}
 
@@ -340,7 +712,7 @@ func buildQueriesForRipoff(maxConcurrency int, manager *PluginManager, primaryKe
 		go func(rowId string, row Row) {
 			defer wg.Done()
 			defer func() { <-semaphore }()
-			query, dependencies, err := buildQueryForRow(manager, primaryKeys, rowId, row)
+			query, dependencies, err := buildQueryForRow(vc, primaryKeys, rowId, row)
 			rowChan <- rowChanItem{rowId, query, dependencies, err}
 		}(rowId, row)
 	}
@@ -2,16 +2,21 @@ package ripoff
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"math/rand"
 	"os"
 	"path"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/brianvoe/gofakeit/v7"
 	"github.com/jackc/pgx/v5"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func runTestData(t *testing.T, ctx context.Context, tx pgx.Tx, testDir string) {
@@ -86,3 +91,107 @@ func TestRipoff(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+// Gofakeit methods with the same name as a ripoff valueFunc can't be called, so they shouldn't be listed.
+func TestFakerValueFuncs(t *testing.T) {
+	vc := newTestValueFuncContext()
+	hashBytes := seedHash("", "seed")
+	newFaker := func() *gofakeit.Faker {
+		return gofakeit.NewFaker(rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hashBytes[:])))), true)
+	}
+	for _, signature := range FakerValueFuncs() {
+		name, params, _ := strings.Cut(signature, "(")
+		require.NotContains(t, builtinValueFuncs, name)
+		require.NotContains(t, structuredValueFuncs, name)
+		if params != "seed)" {
+			continue
+		}
+		// Catches valueFuncs that were added to prepareValue but not to builtinValueFuncs.
+		expected, err := callFakerMethod(name, newFaker(), "seed")
+		require.NoError(t, err)
+		repeated, err := callFakerMethod(name, newFaker(), "seed")
+		require.NoError(t, err)
+		if expected != repeated {
+			// Some methods depend on the current time.
+			continue
+		}
+		value, _, err := prepareValue(vc, "", "", name+"(seed)")
+		require.NoError(t, err)
+		require.Equal(t, expected, value, "%s is shadowed by a ripoff valueFunc", name)
+	}
+	require.True(t, slices.IsSorted(FakerValueFuncs()))
+	_, _, err := prepareValue(vc, "", "", "imagePng(seed, -1, -1)")
+	require.ErrorContains(t, err, "gofakeit method not found")
+	// A nil faker panics, which is reported as an error instead of crashing ripoff.
+	_, err = callFakerMethod("email", nil, "seed")
+	require.ErrorContains(t, err, "gofakeit method email failed")
+}
+
+func newTestValueFuncContext() *valueFuncContext {
+	return newValueFuncContext(&PluginManager{valueFuncMap: map[string]RipoffPlugin{}}, RipoffFile{}, EnumValuesResult{})
+}
+
+func TestPasswordHashArgs(t *testing.T) {
+	vc := newTestValueFuncContext()
+	// Quoted passwords can contain commas.
+	hash, _, err := prepareValue(vc, "", "", `bcrypt("pa,ss", 4)`)
+	require.NoError(t, err)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("pa,ss")))
+	hash, _, err = prepareValue(vc, "", "", `bcrypt('pa, ss')`)
+	require.NoError(t, err)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("pa, ss")))
+	hash, _, err = prepareValue(vc, "", "", `argon2id("pa,ss", 1024, 1, 1)`)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	// Unquoted commas would otherwise silently change the password.
+	for _, value := range []string{`bcrypt(pa,ss)`, `bcrypt(pa, 4, ss)`, `bcrypt("pa,ss)`, `bcrypt("pa" ss)`, `argon2id(pa, 1024, 1, 1, ss)`} {
+		_, _, err = prepareValue(vc, "", "", value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestChoiceParameters(t *testing.T) {
+	vc := newTestValueFuncContext()
+	for i := 0; i < 1000; i++ {
+		value, _, err := prepareValue(vc, "", "", fmt.Sprintf("float(seed%d, 99.98, 99.99, 2)", i))
+		require.NoError(t, err)
+		require.Equal(t, "99.98", value)
+		value, _, err = prepareValue(vc, "", "", fmt.Sprintf("float(seed%d, 0.07, 0.1, 2)", i))
+		require.NoError(t, err)
+		require.Contains(t, []string{"0.07", "0.08", "0.09"}, value)
+	}
+	value, _, err := prepareValue(vc, "", "", "weighted(seed, a:1, b: 2)")
+	require.NoError(t, err)
+	require.Contains(t, []string{"a", "b"}, value)
+	for _, value := range []string{"float(seed, 99.98, 99.99, 1)", "float(seed, 1, 2, -1)", "bool(seed, 1.5)", "bool(seed, -0.1)"} {
+		_, _, err := prepareValue(vc, "", "", value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestDistributionParameters(t *testing.T) {
+	vc := newTestValueFuncContext()
+	for _, value := range []string{"normal(seed, 100, 15)", "lognormal(seed, 0, 0.5)", "exponential(seed, 10)", "zipf(seed, 1.5, 1, 100)"} {
+		_, _, err := prepareValue(vc, "", "", value)
+		require.NoError(t, err, value)
+	}
+	for _, value := range []string{"normal(seed, 100, 0)", "normal(seed, 100, -15)", "lognormal(seed, 0, 0)", "lognormal(seed, 0, -0.5)", "exponential(seed, 0)", "exponential(seed, -10)", "zipf(seed, 1, 1, 100)"} {
+		_, _, err := prepareValue(vc, "", "", value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestPolygonNearPoles(t *testing.T) {
+	vc := newTestValueFuncContext()
+	for _, value := range []string{"polygonWKT(seed, 89.9, 0, 500)", "polygonWKT(seed, -89.9, 0, 500, 12)", "polygonWKT(seed, 0, 179.99, 500)"} {
+		polygon, _, err := prepareValue(vc, "", "", value)
+		require.NoError(t, err, value)
+		require.NotContains(t, polygon, "Inf")
+		require.NotContains(t, polygon, "NaN")
+	}
+	for _, value := range []string{"polygonWKT(seed, 90, 0, 500)", "polygonWKT(seed, -90, 0, 500)", "polygonWKT(seed, 89.99, 0, 5000)", "polygonWKT(seed, 91, 0, 500)", "polygonWKT(seed, 0, 179.999, 500)", "polygonWKT(seed, 60, -179.99, 1000)"} {
+		_, _, err := prepareValue(vc, "", "", value)
+		require.Error(t, err, value)
+	}
+}
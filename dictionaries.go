@@ -0,0 +1,152 @@
+package ripoff
+
+import (
+	"encoding/csv"
+	"fmt"
+	randv2 "math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A list of words that valueFuncs and templates can pick from. Dictionaries can be defined in yaml as:
+// - A list of values, ex: [free, pro, enterprise]
+// - A map of values to weights, ex: {free: 80, pro: 20}
+// - A path to a .txt file with one value per line, or a .csv file with values and optional weights
+type Dictionary struct {
+	Values []string
+	// Relative weights for each value, or nil if every value is equally likely.
+	Weights []float64
+	// Path to a .txt or .csv file, relative to the ripoff file that defined the dictionary.
+	Path string
+}
+
+func (d *Dictionary) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		d.Path = node.Value
+		return nil
+	case yaml.SequenceNode:
+		return node.Decode(&d.Values)
+	case yaml.MappingNode:
+		// Use the node directly, since decoding to a map would lose the order of values.
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			weight, err := strconv.ParseFloat(node.Content[i+1].Value, 64)
+			if err != nil {
+				return fmt.Errorf("dictionary value %s has an invalid weight: %w", node.Content[i].Value, err)
+			}
+			d.Values = append(d.Values, node.Content[i].Value)
+			d.Weights = append(d.Weights, weight)
+		}
+		return nil
+	}
+	return fmt.Errorf("dictionaries must be a list, a map of values to weights, or a file path")
+}
+
+func (d Dictionary) MarshalYAML() (interface{}, error) {
+	if d.Path != "" {
+		return d.Path, nil
+	}
+	if d.Weights == nil {
+		return d.Values, nil
+	}
+	weights := &yaml.Node{Kind: yaml.MappingNode}
+	for i, value := range d.Values {
+		weights.Content = append(weights.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: strconv.FormatFloat(d.Weights[i], 'f', -1, 64)},
+		)
+	}
+	return weights, nil
+}
+
+// Loads values from the dictionary's file, if it has one.
+func (d *Dictionary) load(dir string) error {
+	if d.Path == "" {
+		return nil
+	}
+	path := filepath.Join(dir, d.Path)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch filepath.Ext(path) {
+	case ".txt":
+		// One value per line, ignoring empty lines and comments.
+		for _, line := range strings.Split(string(contents), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			d.Values = append(d.Values, line)
+		}
+	case ".csv":
+		// The first column is the value, and the optional second column is the weight.
+		reader := csv.NewReader(strings.NewReader(string(contents)))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return fmt.Errorf("invalid dictionary file %s: %w", path, err)
+		}
+		for _, record := range records {
+			d.Values = append(d.Values, record[0])
+			if len(record) > 1 {
+				weight, err := strconv.ParseFloat(record[1], 64)
+				if err != nil {
+					return fmt.Errorf("dictionary value %s in %s has an invalid weight: %w", record[0], path, err)
+				}
+				d.Weights = append(d.Weights, weight)
+			}
+		}
+		if d.Weights != nil && len(d.Weights) != len(d.Values) {
+			return fmt.Errorf("some values in %s are missing weights", path)
+		}
+	default:
+		return fmt.Errorf("dictionary files must be .txt or .csv files, got %s", path)
+	}
+	return nil
+}
+
+// Picks a value from the dictionary, respecting weights if it has them.
+func (d Dictionary) pick(r *randv2.Rand) (string, error) {
+	if len(d.Values) == 0 {
+		return "", fmt.Errorf("dictionary is empty")
+	}
+	if d.Weights == nil {
+		return d.Values[r.IntN(len(d.Values))], nil
+	}
+	index, err := weightedIndex(r, d.Weights)
+	if err != nil {
+		return "", err
+	}
+	return d.Values[index], nil
+}
+
+// Picks a value from a dictionary defined in a ripoff file.
+func randomFromList(r *randv2.Rand, dictionaries map[string]Dictionary, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("fromList requires a dictionary name, ex: fromList(seed, plans)")
+	}
+	dictionary, hasDictionary := dictionaries[args[0]]
+	if !hasDictionary {
+		return "", fmt.Errorf("fromList could not find dictionary %s", args[0])
+	}
+	value, err := dictionary.pick(r)
+	if err != nil {
+		return "", fmt.Errorf("fromList(%s): %w", args[0], err)
+	}
+	return value, nil
+}
+
+// Lists the values in every dictionary, for use in templates.
+func dictionaryValues(dictionaries map[string]Dictionary) map[string][]string {
+	values := map[string][]string{}
+	for name, dictionary := range dictionaries {
+		values[name] = dictionary.Values
+	}
+	return values
+}
@@ -0,0 +1,95 @@
+package ripoff
+
+import (
+	"fmt"
+	"math"
+	randv2 "math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Options that can be passed to any distribution valueFunc after its parameters, ex: normal(seed, 100, 15, min=0, round=0).
+var distributionOptions = []string{"min", "max", "round"}
+
+// Number of parameters each distribution requires, not including the seed.
+var distributionParameterCounts = map[string]int{
+	"normal":      2,
+	"lognormal":   2,
+	"exponential": 1,
+	"zipf":        3,
+}
+
+// Generates a number from a statistical distribution, optionally clamped and rounded.
+func randomFromDistribution(distribution string, r *randv2.Rand, args []string) (string, error) {
+	parameters := []float64{}
+	options := map[string]float64{}
+	for _, arg := range args {
+		key, optionValue, isOption := strings.Cut(arg, "=")
+		if isOption {
+			key = strings.TrimSpace(key)
+			if !slices.Contains(distributionOptions, key) {
+				return "", fmt.Errorf("unknown %s option %s, expected one of: %s", distribution, key, strings.Join(distributionOptions, ", "))
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(optionValue), 64)
+			if err != nil {
+				return "", err
+			}
+			options[key] = parsed
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil {
+			return "", err
+		}
+		parameters = append(parameters, parsed)
+	}
+	if len(parameters) != distributionParameterCounts[distribution] {
+		return "", fmt.Errorf("%s requires %d parameters, got %d", distribution, distributionParameterCounts[distribution], len(parameters))
+	}
+
+	var result float64
+	switch distribution {
+	case "normal":
+		// Parameters: mean, standard deviation.
+		if parameters[1] <= 0 {
+			return "", fmt.Errorf("normal requires a standard deviation > 0")
+		}
+		result = r.NormFloat64()*parameters[1] + parameters[0]
+	case "lognormal":
+		// Parameters: mean and standard deviation of the underlying normal distribution.
+		if parameters[1] <= 0 {
+			return "", fmt.Errorf("lognormal requires a standard deviation > 0")
+		}
+		result = math.Exp(r.NormFloat64()*parameters[1] + parameters[0])
+	case "exponential":
+		// Parameters: mean.
+		if parameters[0] <= 0 {
+			return "", fmt.Errorf("exponential requires a mean > 0")
+		}
+		result = r.ExpFloat64() * parameters[0]
+	case "zipf":
+		// Parameters: s, v, and max, as described in math/rand/v2.NewZipf.
+		if parameters[0] <= 1 || parameters[1] < 1 || parameters[2] < 0 {
+			return "", fmt.Errorf("zipf requires s > 1, v >= 1, and max >= 0")
+		}
+		result = float64(randv2.NewZipf(r, parameters[0], parameters[1], uint64(parameters[2])).Uint64())
+	default:
+		return "", fmt.Errorf("unknown distribution %s", distribution)
+	}
+
+	min, hasMin := options["min"]
+	if hasMin {
+		result = math.Max(result, min)
+	}
+	max, hasMax := options["max"]
+	if hasMax {
+		result = math.Min(result, max)
+	}
+	decimals := -1
+	round, hasRound := options["round"]
+	if hasRound {
+		decimals = int(round)
+	}
+	return strconv.FormatFloat(result, 'f', decimals, 64), nil
+}
@@ -0,0 +1,197 @@
+package ripoff
+
+import (
+	"fmt"
+	"math"
+	randv2 "math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Approximate meters per degree of latitude.
+const metersPerDegree = 111320
+
+type latLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Coordinates are rounded to 6 decimals, which is about 10cm.
+func roundCoordinate(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Generates a point in a bounding box, which defaults to the whole world.
+// Args are in the format: [minLat, minLng, maxLat, maxLng], and any remaining args are returned.
+func randomLatLng(r *randv2.Rand, args []string) (latLng, []string, error) {
+	bounds := []float64{-90, -180, 90, 180}
+	if len(args) >= 4 {
+		for i, arg := range args[:4] {
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return latLng{}, nil, err
+			}
+			bounds[i] = bound
+		}
+		args = args[4:]
+	}
+	minLat, minLng, maxLat, maxLng := bounds[0], bounds[1], bounds[2], bounds[3]
+	if minLat < -90 || maxLat > 90 || minLat >= maxLat || minLng < -180 || maxLng > 180 || minLng >= maxLng {
+		return latLng{}, nil, fmt.Errorf("invalid bounding box, expected minLat, minLng, maxLat, maxLng within -90 to 90 and -180 to 180")
+	}
+	// Pick from the sine of the latitude so that points are evenly distributed by area, not clustered at the poles.
+	minSin, maxSin := math.Sin(minLat*math.Pi/180), math.Sin(maxLat*math.Pi/180)
+	lat := math.Asin(minSin+r.Float64()*(maxSin-minSin)) * 180 / math.Pi
+	lng := minLng + r.Float64()*(maxLng-minLng)
+	return latLng{Lat: roundCoordinate(lat), Lng: roundCoordinate(lng)}, args, nil
+}
+
+// Generates a point, returning a single field if a path is provided (lat or lng) or JSON otherwise.
+func randomLatLngValue(r *randv2.Rand, args []string, fieldPath string) (string, error) {
+	point, args, err := randomLatLng(r, args)
+	if err != nil {
+		return "", err
+	}
+	if len(args) > 0 {
+		return "", fmt.Errorf("latlng accepts a bounding box, ex: latlng(seed, 52.33, 13.08, 52.68, 13.76)")
+	}
+	if fieldPath == "" {
+		return formatFakerResult(reflect.ValueOf(point))
+	}
+	field, err := lookupField(reflect.ValueOf(point), fieldPath)
+	if err != nil {
+		return "", fmt.Errorf("latlng(): %w", err)
+	}
+	return formatFakerResult(field)
+}
+
+// Generates a point in EWKT, which PostGIS geometry and geography columns accept, ex: SRID=4326;POINT(13.4 52.5)
+func randomPointWKT(r *randv2.Rand, args []string) (string, error) {
+	point, args, err := randomLatLng(r, args)
+	if err != nil {
+		return "", err
+	}
+	if len(args) > 0 {
+		return "", fmt.Errorf("pointWKT accepts a bounding box, ex: pointWKT(seed, 52.33, 13.08, 52.68, 13.76)")
+	}
+	// WKT coordinates are in x y (longitude latitude) order.
+	return fmt.Sprintf("SRID=4326;POINT(%s %s)", formatCoordinate(point.Lng), formatCoordinate(point.Lat)), nil
+}
+
+// Generates a polygon around a center point in EWKT. Args are in the format: lat, lng, radius in meters, [vertices]
+func randomPolygonWKT(r *randv2.Rand, args []string) (string, error) {
+	if len(args) != 3 && len(args) != 4 {
+		return "", fmt.Errorf("polygonWKT requires a center, radius in meters, and optionally a number of vertices, ex: polygonWKT(seed, 52.52, 13.405, 500, 6)")
+	}
+	params := []float64{}
+	for _, arg := range args[:3] {
+		param, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+	}
+	lat, lng, radius := params[0], params[1], params[2]
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return "", fmt.Errorf("polygonWKT center must be within -90 to 90 and -180 to 180, got %g, %g", lat, lng)
+	}
+	if radius <= 0 {
+		return "", fmt.Errorf("polygonWKT radius must be positive, got %g", radius)
+	}
+	// Longitude offsets are scaled by the latitude, which isn't possible at the poles.
+	if math.Abs(lat)+radius/metersPerDegree >= 90 {
+		return "", fmt.Errorf("polygonWKT cannot include a pole, got a radius of %gm around %g, %g", radius, lat, lng)
+	}
+	// Likewise, longitudes aren't wrapped, so the polygon can't cross the antimeridian.
+	if math.Abs(lng)+radius/(metersPerDegree*math.Cos(lat*math.Pi/180)) > 180 {
+		return "", fmt.Errorf("polygonWKT cannot cross the antimeridian (180 degrees longitude), got a radius of %gm around %g, %g", radius, lat, lng)
+	}
+	vertices := 6
+	if len(args) == 4 {
+		var err error
+		vertices, err = strconv.Atoi(args[3])
+		if err != nil {
+			return "", err
+		}
+		if vertices < 3 {
+			return "", fmt.Errorf("polygonWKT requires at least 3 vertices, got %d", vertices)
+		}
+	}
+	// Vertices are at increasing angles around the center, so the polygon never intersects itself.
+	angles := make([]float64, vertices)
+	for i := range angles {
+		angles[i] = (float64(i) + r.Float64()*0.8) * 2 * math.Pi / float64(vertices)
+	}
+	slices.Sort(angles)
+	points := []string{}
+	for _, angle := range angles {
+		distance := radius * (0.5 + r.Float64()*0.5)
+		vertexLat := lat + distance*math.Sin(angle)/metersPerDegree
+		vertexLng := lng + distance*math.Cos(angle)/(metersPerDegree*math.Cos(lat*math.Pi/180))
+		points = append(points, formatCoordinate(roundCoordinate(vertexLng))+" "+formatCoordinate(roundCoordinate(vertexLat)))
+	}
+	// Polygon rings are closed by repeating the first point.
+	points = append(points, points[0])
+	return fmt.Sprintf("SRID=4326;POLYGON((%s))", strings.Join(points, ",")), nil
+}
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Encodes a point as a geohash with the given number of characters.
+func encodeGeohash(point latLng, precision int) string {
+	latRange := []float64{-90, 90}
+	lngRange := []float64{-180, 180}
+	hash := strings.Builder{}
+	bits, bitCount := 0, 0
+	// Bits alternate between longitude and latitude, starting with longitude.
+	isLng := true
+	for hash.Len() < precision {
+		valueRange, value := latRange, point.Lat
+		if isLng {
+			valueRange, value = lngRange, point.Lng
+		}
+		mid := (valueRange[0] + valueRange[1]) / 2
+		bits <<= 1
+		if value >= mid {
+			bits |= 1
+			valueRange[0] = mid
+		} else {
+			valueRange[1] = mid
+		}
+		isLng = !isLng
+		bitCount++
+		if bitCount == 5 {
+			hash.WriteByte(geohashAlphabet[bits])
+			bits, bitCount = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// Generates a geohash. Args are in the format: [minLat, minLng, maxLat, maxLng], [precision]
+func randomGeohash(r *randv2.Rand, args []string) (string, error) {
+	point, args, err := randomLatLng(r, args)
+	if err != nil {
+		return "", err
+	}
+	precision := 9
+	if len(args) > 1 {
+		return "", fmt.Errorf("geohash accepts a bounding box and precision, ex: geohash(seed, 52.33, 13.08, 52.68, 13.76, 7)")
+	}
+	if len(args) == 1 {
+		precision, err = strconv.Atoi(args[0])
+		if err != nil {
+			return "", err
+		}
+		if precision < 1 || precision > 12 {
+			return "", fmt.Errorf("geohash precision must be between 1 and 12, got %d", precision)
+		}
+	}
+	return encodeGeohash(point, precision), nil
+}
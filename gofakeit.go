@@ -1,482 +1,290 @@
 package ripoff
 
 import (
+	"encoding/json"
 	"fmt"
+	"image"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/lib/pq"
 )
 
-// Helper method to cast user-supplied args to ints if provided.
-func defaultArgsInt(args []string, defaults []int) ([]int, error) {
-	if len(args) != len(defaults) {
-		return defaults, nil
+// A gofakeit.Faker method that can be called as a valueFunc.
+type fakerMethod struct {
+	method reflect.Method
+	// Used when fewer arguments than parameters are provided, for backwards compatibility.
+	defaultArgs []string
+}
+
+// Names that can't be derived from the Go method name.
+var fakerMethodNameOverrides = map[string]string{
+	"BS":          "bs",
+	"SSN":         "ssn",
+	"URL":         "url",
+	"IPv4Address": "ipv4Address",
+	"IPv6Address": "ipv6Address",
+}
+
+var fakerMethodDefaultArgs = map[string][]string{
+	"LoremIpsumSentence":  {"20"},
+	"LoremIpsumParagraph": {"1", "4", "20", ""},
+}
+
+// All callable gofakeit.Faker methods, keyed by valueFunc name.
+var fakerMethods, fakerMethodAliases = buildFakerMethods()
+
+// Converts a Go method name to a valueFunc name, ex: HTTPMethod => httpMethod.
+func fakerMethodName(goName string) string {
+	override, hasOverride := fakerMethodNameOverrides[goName]
+	if hasOverride {
+		return override
 	}
-	ret := make([]int, len(defaults))
-	for i := 0; i < len(defaults); i++ {
-		argInt, err := strconv.Atoi(args[i])
-		if err != nil {
-			return []int{}, err
+	runes := []rune(goName)
+	upperCount := 0
+	for upperCount < len(runes) && unicode.IsUpper(runes[upperCount]) {
+		upperCount++
+	}
+	// The last upper case letter of an acronym usually starts the next word.
+	if upperCount > 1 && upperCount < len(runes) && unicode.IsLower(runes[upperCount]) {
+		upperCount--
+	}
+	for i := 0; i < upperCount || i == 0; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// Determines if we know how to convert a valueFunc argument to the given type.
+func isSupportedFakerParam(paramType reflect.Type) bool {
+	if paramType == reflect.TypeFor[time.Time]() {
+		return true
+	}
+	switch paramType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return paramType.Elem().Kind() != reflect.Slice && isSupportedFakerParam(paramType.Elem())
+	}
+	return false
+}
+
+// Methods that can be called generically, but are better served by ripoff's own valueFuncs.
+var excludedFakerMethods = []string{"ImagePng", "ImageJpeg"}
+
+// Finds every gofakeit.Faker method with arguments and results that can be used in SQL.
+func buildFakerMethods() (map[string]fakerMethod, map[string]string) {
+	methods := map[string]fakerMethod{}
+	aliases := map[string]string{}
+	fakerType := reflect.TypeFor[*gofakeit.Faker]()
+	errorType := reflect.TypeFor[error]()
+	// Images are better served by ripoff's own image valueFunc.
+	imageType := reflect.TypeFor[*image.RGBA]()
+	for i := 0; i < fakerType.NumMethod(); i++ {
+		method := fakerType.Method(i)
+		if slices.Contains(excludedFakerMethods, method.Name) {
+			continue
+		}
+		// The first input is the receiver.
+		supported := !method.Type.IsVariadic() && method.Type.NumOut() > 0
+		for j := 1; j < method.Type.NumIn(); j++ {
+			paramType := method.Type.In(j)
+			isLast := j == method.Type.NumIn()-1
+			supported = supported && isSupportedFakerParam(paramType) && (isLast || paramType.Kind() != reflect.Slice)
+		}
+		if method.Type.NumOut() > 0 {
+			outType := method.Type.Out(0)
+			supported = supported && outType != errorType && outType.Kind() != reflect.Interface && outType != imageType
+		}
+		if method.Type.NumOut() == 2 {
+			supported = supported && method.Type.Out(1) == errorType
+		}
+		if !supported || method.Type.NumOut() > 2 {
+			continue
+		}
+		name := fakerMethodName(method.Name)
+		methods[name] = fakerMethod{
+			method:      method,
+			defaultArgs: fakerMethodDefaultArgs[method.Name],
+		}
+		// Older versions of ripoff only lowercased the first letter.
+		legacyName := strings.ToLower(method.Name[:1]) + method.Name[1:]
+		if legacyName != name {
+			aliases[legacyName] = name
 		}
-		ret[i] = argInt
 	}
-	return ret, nil
+	return methods, aliases
 }
 
-// Calls a `func() string` shaped method in a given faker instance.
-func callFakerMethod(method string, faker *gofakeit.Faker, args ...string) (string, error) {
-	switch method {
-	case "loremIpsumSentence":
-		argsInt, err := defaultArgsInt(args[1:], []int{20})
+// Converts a valueFunc argument to a parameter of a gofakeit.Faker method.
+func convertFakerArg(arg string, paramType reflect.Type) (reflect.Value, error) {
+	if paramType == reflect.TypeFor[time.Time]() {
+		parsed, err := parseTime(arg, time.UTC)
+		return reflect.ValueOf(parsed), err
+	}
+	value := reflect.New(paramType).Elem()
+	switch paramType.Kind() {
+	case reflect.String:
+		value.SetString(arg)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(arg)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(arg, 10, paramType.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(arg, 10, paramType.Bits())
 		if err != nil {
-			return "", err
+			return value, err
 		}
-		return faker.LoremIpsumSentence(argsInt[0]), nil
-	case "loremIpsumParagraph":
-		argsInt, err := defaultArgsInt(args[1:], []int{1, 4, 20})
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(arg, paramType.Bits())
 		if err != nil {
-			return "", err
+			return value, err
 		}
-		return faker.LoremIpsumParagraph(argsInt[0], argsInt[1], argsInt[2], ""), nil
-	case "achAccount":
-		return faker.AchAccount(), nil
-	case "achRouting":
-		return faker.AchRouting(), nil
-	case "adjective":
-		return faker.Adjective(), nil
-	case "adjectiveDemonstrative":
-		return faker.AdjectiveDemonstrative(), nil
-	case "adjectiveDescriptive":
-		return faker.AdjectiveDescriptive(), nil
-	case "adjectiveIndefinite":
-		return faker.AdjectiveIndefinite(), nil
-	case "adjectiveInterrogative":
-		return faker.AdjectiveInterrogative(), nil
-	case "adjectivePossessive":
-		return faker.AdjectivePossessive(), nil
-	case "adjectiveProper":
-		return faker.AdjectiveProper(), nil
-	case "adjectiveQuantitative":
-		return faker.AdjectiveQuantitative(), nil
-	case "adverb":
-		return faker.Adverb(), nil
-	case "adverbDegree":
-		return faker.AdverbDegree(), nil
-	case "adverbFrequencyDefinite":
-		return faker.AdverbFrequencyDefinite(), nil
-	case "adverbFrequencyIndefinite":
-		return faker.AdverbFrequencyIndefinite(), nil
-	case "adverbManner":
-		return faker.AdverbManner(), nil
-	case "adverbPlace":
-		return faker.AdverbPlace(), nil
-	case "adverbTimeDefinite":
-		return faker.AdverbTimeDefinite(), nil
-	case "adverbTimeIndefinite":
-		return faker.AdverbTimeIndefinite(), nil
-	case "animal":
-		return faker.Animal(), nil
-	case "animalType":
-		return faker.AnimalType(), nil
-	case "appAuthor":
-		return faker.AppAuthor(), nil
-	case "appName":
-		return faker.AppName(), nil
-	case "appVersion":
-		return faker.AppVersion(), nil
-	case "bs":
-		return faker.BS(), nil
-	case "beerAlcohol":
-		return faker.BeerAlcohol(), nil
-	case "beerBlg":
-		return faker.BeerBlg(), nil
-	case "beerHop":
-		return faker.BeerHop(), nil
-	case "beerIbu":
-		return faker.BeerIbu(), nil
-	case "beerMalt":
-		return faker.BeerMalt(), nil
-	case "beerName":
-		return faker.BeerName(), nil
-	case "beerStyle":
-		return faker.BeerStyle(), nil
-	case "beerYeast":
-		return faker.BeerYeast(), nil
-	case "bird":
-		return faker.Bird(), nil
-	case "bitcoinAddress":
-		return faker.BitcoinAddress(), nil
-	case "bitcoinPrivateKey":
-		return faker.BitcoinPrivateKey(), nil
-	case "blurb":
-		return faker.Blurb(), nil
-	case "bookAuthor":
-		return faker.BookAuthor(), nil
-	case "bookGenre":
-		return faker.BookGenre(), nil
-	case "bookTitle":
-		return faker.BookTitle(), nil
-	case "breakfast":
-		return faker.Breakfast(), nil
-	case "buzzWord":
-		return faker.BuzzWord(), nil
-	case "carFuelType":
-		return faker.CarFuelType(), nil
-	case "carMaker":
-		return faker.CarMaker(), nil
-	case "carModel":
-		return faker.CarModel(), nil
-	case "carTransmissionType":
-		return faker.CarTransmissionType(), nil
-	case "carType":
-		return faker.CarType(), nil
-	case "cat":
-		return faker.Cat(), nil
-	case "celebrityActor":
-		return faker.CelebrityActor(), nil
-	case "celebrityBusiness":
-		return faker.CelebrityBusiness(), nil
-	case "celebritySport":
-		return faker.CelebritySport(), nil
-	case "chromeUserAgent":
-		return faker.ChromeUserAgent(), nil
-	case "city":
-		return faker.City(), nil
-	case "color":
-		return faker.Color(), nil
-	case "comment":
-		return faker.Comment(), nil
-	case "company":
-		return faker.Company(), nil
-	case "companySuffix":
-		return faker.CompanySuffix(), nil
-	case "connective":
-		return faker.Connective(), nil
-	case "connectiveCasual":
-		return faker.ConnectiveCasual(), nil
-	case "connectiveComparative":
-		return faker.ConnectiveComparative(), nil
-	case "connectiveComplaint":
-		return faker.ConnectiveComplaint(), nil
-	case "connectiveExamplify":
-		return faker.ConnectiveExamplify(), nil
-	case "connectiveListing":
-		return faker.ConnectiveListing(), nil
-	case "connectiveTime":
-		return faker.ConnectiveTime(), nil
-	case "country":
-		return faker.Country(), nil
-	case "countryAbr":
-		return faker.CountryAbr(), nil
-	case "creditCardCvv":
-		return faker.CreditCardCvv(), nil
-	case "creditCardExp":
-		return faker.CreditCardExp(), nil
-	case "creditCardType":
-		return faker.CreditCardType(), nil
-	case "currencyLong":
-		return faker.CurrencyLong(), nil
-	case "currencyShort":
-		return faker.CurrencyShort(), nil
-	case "cusip":
-		return faker.Cusip(), nil
-	case "dessert":
-		return faker.Dessert(), nil
-	case "digit":
-		return faker.Digit(), nil
-	case "dinner":
-		return faker.Dinner(), nil
-	case "dog":
-		return faker.Dog(), nil
-	case "domainName":
-		return faker.DomainName(), nil
-	case "domainSuffix":
-		return faker.DomainSuffix(), nil
-	case "drink":
-		return faker.Drink(), nil
-	case "email":
-		return faker.Email(), nil
-	case "emoji":
-		return faker.Emoji(), nil
-	case "emojiAlias":
-		return faker.EmojiAlias(), nil
-	case "emojiCategory":
-		return faker.EmojiCategory(), nil
-	case "emojiDescription":
-		return faker.EmojiDescription(), nil
-	case "emojiTag":
-		return faker.EmojiTag(), nil
-	case "farmAnimal":
-		return faker.FarmAnimal(), nil
-	case "fileExtension":
-		return faker.FileExtension(), nil
-	case "fileMimeType":
-		return faker.FileMimeType(), nil
-	case "firefoxUserAgent":
-		return faker.FirefoxUserAgent(), nil
-	case "firstName":
-		return faker.FirstName(), nil
-	case "flipACoin":
-		return faker.FlipACoin(), nil
-	case "fruit":
-		return faker.Fruit(), nil
-	case "gamertag":
-		return faker.Gamertag(), nil
-	case "gender":
-		return faker.Gender(), nil
-	case "hTTPMethod":
-		return faker.HTTPMethod(), nil
-	case "hTTPVersion":
-		return faker.HTTPVersion(), nil
-	case "hackerAbbreviation":
-		return faker.HackerAbbreviation(), nil
-	case "hackerAdjective":
-		return faker.HackerAdjective(), nil
-	case "hackerNoun":
-		return faker.HackerNoun(), nil
-	case "hackerPhrase":
-		return faker.HackerPhrase(), nil
-	case "hackerVerb":
-		return faker.HackerVerb(), nil
-	case "hackeringVerb":
-		return faker.HackeringVerb(), nil
-	case "hexColor":
-		return faker.HexColor(), nil
-	case "hipsterWord":
-		return faker.HipsterWord(), nil
-	case "hobby":
-		return faker.Hobby(), nil
-	case "iPv4Address":
-		return faker.IPv4Address(), nil
-	case "iPv6Address":
-		return faker.IPv6Address(), nil
-	case "inputName":
-		return faker.InputName(), nil
-	case "interjection":
-		return faker.Interjection(), nil
-	case "isin":
-		return faker.Isin(), nil
-	case "jobDescriptor":
-		return faker.JobDescriptor(), nil
-	case "jobLevel":
-		return faker.JobLevel(), nil
-	case "jobTitle":
-		return faker.JobTitle(), nil
-	case "language":
-		return faker.Language(), nil
-	case "languageAbbreviation":
-		return faker.LanguageAbbreviation(), nil
-	case "languageBCP":
-		return faker.LanguageBCP(), nil
-	case "lastName":
-		return faker.LastName(), nil
-	case "letter":
-		return faker.Letter(), nil
-	case "loremIpsumWord":
-		return faker.LoremIpsumWord(), nil
-	case "lunch":
-		return faker.Lunch(), nil
-	case "macAddress":
-		return faker.MacAddress(), nil
-	case "middleName":
-		return faker.MiddleName(), nil
-	case "minecraftAnimal":
-		return faker.MinecraftAnimal(), nil
-	case "minecraftArmorPart":
-		return faker.MinecraftArmorPart(), nil
-	case "minecraftArmorTier":
-		return faker.MinecraftArmorTier(), nil
-	case "minecraftBiome":
-		return faker.MinecraftBiome(), nil
-	case "minecraftDye":
-		return faker.MinecraftDye(), nil
-	case "minecraftFood":
-		return faker.MinecraftFood(), nil
-	case "minecraftMobBoss":
-		return faker.MinecraftMobBoss(), nil
-	case "minecraftMobHostile":
-		return faker.MinecraftMobHostile(), nil
-	case "minecraftMobNeutral":
-		return faker.MinecraftMobNeutral(), nil
-	case "minecraftMobPassive":
-		return faker.MinecraftMobPassive(), nil
-	case "minecraftOre":
-		return faker.MinecraftOre(), nil
-	case "minecraftTool":
-		return faker.MinecraftTool(), nil
-	case "minecraftVillagerJob":
-		return faker.MinecraftVillagerJob(), nil
-	case "minecraftVillagerLevel":
-		return faker.MinecraftVillagerLevel(), nil
-	case "minecraftVillagerStation":
-		return faker.MinecraftVillagerStation(), nil
-	case "minecraftWeapon":
-		return faker.MinecraftWeapon(), nil
-	case "minecraftWeather":
-		return faker.MinecraftWeather(), nil
-	case "minecraftWood":
-		return faker.MinecraftWood(), nil
-	case "monthString":
-		return faker.MonthString(), nil
-	case "movieGenre":
-		return faker.MovieGenre(), nil
-	case "movieName":
-		return faker.MovieName(), nil
-	case "name":
-		return faker.Name(), nil
-	case "namePrefix":
-		return faker.NamePrefix(), nil
-	case "nameSuffix":
-		return faker.NameSuffix(), nil
-	case "noun":
-		return faker.Noun(), nil
-	case "nounAbstract":
-		return faker.NounAbstract(), nil
-	case "nounCollectiveAnimal":
-		return faker.NounCollectiveAnimal(), nil
-	case "nounCollectivePeople":
-		return faker.NounCollectivePeople(), nil
-	case "nounCollectiveThing":
-		return faker.NounCollectiveThing(), nil
-	case "nounCommon":
-		return faker.NounCommon(), nil
-	case "nounConcrete":
-		return faker.NounConcrete(), nil
-	case "nounCountable":
-		return faker.NounCountable(), nil
-	case "nounDeterminer":
-		return faker.NounDeterminer(), nil
-	case "nounProper":
-		return faker.NounProper(), nil
-	case "nounUncountable":
-		return faker.NounUncountable(), nil
-	case "operaUserAgent":
-		return faker.OperaUserAgent(), nil
-	case "petName":
-		return faker.PetName(), nil
-	case "phone":
-		return faker.Phone(), nil
-	case "phoneFormatted":
-		return faker.PhoneFormatted(), nil
-	case "phrase":
-		return faker.Phrase(), nil
-	case "phraseAdverb":
-		return faker.PhraseAdverb(), nil
-	case "phraseNoun":
-		return faker.PhraseNoun(), nil
-	case "phrasePreposition":
-		return faker.PhrasePreposition(), nil
-	case "phraseVerb":
-		return faker.PhraseVerb(), nil
-	case "preposition":
-		return faker.Preposition(), nil
-	case "prepositionCompound":
-		return faker.PrepositionCompound(), nil
-	case "prepositionDouble":
-		return faker.PrepositionDouble(), nil
-	case "prepositionSimple":
-		return faker.PrepositionSimple(), nil
-	case "productCategory":
-		return faker.ProductCategory(), nil
-	case "productDescription":
-		return faker.ProductDescription(), nil
-	case "productFeature":
-		return faker.ProductFeature(), nil
-	case "productMaterial":
-		return faker.ProductMaterial(), nil
-	case "productName":
-		return faker.ProductName(), nil
-	case "productUPC":
-		return faker.ProductUPC(), nil
-	case "programmingLanguage":
-		return faker.ProgrammingLanguage(), nil
-	case "pronoun":
-		return faker.Pronoun(), nil
-	case "pronounDemonstrative":
-		return faker.PronounDemonstrative(), nil
-	case "pronounIndefinite":
-		return faker.PronounIndefinite(), nil
-	case "pronounInterrogative":
-		return faker.PronounInterrogative(), nil
-	case "pronounObject":
-		return faker.PronounObject(), nil
-	case "pronounPersonal":
-		return faker.PronounPersonal(), nil
-	case "pronounPossessive":
-		return faker.PronounPossessive(), nil
-	case "pronounReflective":
-		return faker.PronounReflective(), nil
-	case "pronounRelative":
-		return faker.PronounRelative(), nil
-	case "question":
-		return faker.Question(), nil
-	case "quote":
-		return faker.Quote(), nil
-	case "ssn":
-		return faker.SSN(), nil
-	case "safariUserAgent":
-		return faker.SafariUserAgent(), nil
-	case "safeColor":
-		return faker.SafeColor(), nil
-	case "school":
-		return faker.School(), nil
-	case "sentenceSimple":
-		return faker.SentenceSimple(), nil
-	case "slogan":
-		return faker.Slogan(), nil
-	case "snack":
-		return faker.Snack(), nil
-	case "state":
-		return faker.State(), nil
-	case "stateAbr":
-		return faker.StateAbr(), nil
-	case "street":
-		return faker.Street(), nil
-	case "streetName":
-		return faker.StreetName(), nil
-	case "streetNumber":
-		return faker.StreetNumber(), nil
-	case "streetPrefix":
-		return faker.StreetPrefix(), nil
-	case "streetSuffix":
-		return faker.StreetSuffix(), nil
-	case "timeZone":
-		return faker.TimeZone(), nil
-	case "timeZoneAbv":
-		return faker.TimeZoneAbv(), nil
-	case "timeZoneFull":
-		return faker.TimeZoneFull(), nil
-	case "timeZoneRegion":
-		return faker.TimeZoneRegion(), nil
-	case "url":
-		return faker.URL(), nil
-	case "userAgent":
-		return faker.UserAgent(), nil
-	case "username":
-		return faker.Username(), nil
-	case "vegetable":
-		return faker.Vegetable(), nil
-	case "verb":
-		return faker.Verb(), nil
-	case "verbAction":
-		return faker.VerbAction(), nil
-	case "verbHelping":
-		return faker.VerbHelping(), nil
-	case "verbIntransitive":
-		return faker.VerbIntransitive(), nil
-	case "verbLinking":
-		return faker.VerbLinking(), nil
-	case "verbTransitive":
-		return faker.VerbTransitive(), nil
-	case "vowel":
-		return faker.Vowel(), nil
-	case "weekDay":
-		return faker.WeekDay(), nil
-	case "word":
-		return faker.Word(), nil
-	case "zip":
-		return faker.Zip(), nil
+		value.SetFloat(parsed)
 	default:
+		return value, fmt.Errorf("unsupported gofakeit argument type %s", paramType)
+	}
+	return value, nil
+}
+
+// Formats the result of a gofakeit.Faker method so that it can be used in SQL.
+func formatFakerResult(result reflect.Value) (string, error) {
+	switch typed := result.Interface().(type) {
+	case string:
+		return typed, nil
+	case time.Time:
+		return typed.Format(time.RFC3339), nil
+	case []byte:
+		return formatBytea(typed), nil
+	}
+	switch result.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(result.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(result.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(result.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(result.Float(), 'f', -1, result.Type().Bits()), nil
+	case reflect.Slice:
+		// Postgres array literal, ex: {"a","b"}
+		arrayValue, err := pq.Array(result.Interface()).Value()
+		if err == nil {
+			return fmt.Sprint(arrayValue), nil
+		}
+	}
+	// Structs and maps are assumed to be stored in JSON columns.
+	jsonResult, err := json.Marshal(result.Interface())
+	return string(jsonResult), err
+}
+
+// Calls a gofakeit.Faker method, converting args to the method's parameter types.
+// The first arg is always the seed, which is ignored.
+func callFakerMethod(method string, faker *gofakeit.Faker, args ...string) (result string, err error) {
+	// Arguments come from ripoff files, and some methods panic on values they don't expect.
+	defer func() {
+		recovered := recover()
+		if recovered != nil {
+			result = ""
+			err = fmt.Errorf("gofakeit method %s failed: %v", method, recovered)
+		}
+	}()
+	alias, hasAlias := fakerMethodAliases[method]
+	if hasAlias {
+		method = alias
+	}
+	fm, hasMethod := fakerMethods[method]
+	if !hasMethod {
 		return "", fmt.Errorf("gofakeit method not found: %s", method)
 	}
+	methodType := fm.method.Type
+	paramCount := methodType.NumIn() - 1
+	if len(args) > 0 {
+		args = args[1:]
+	}
+	// Zero parameter methods historically ignored extra arguments.
+	if paramCount == 0 {
+		args = []string{}
+	}
+	// Slices are only supported as the last parameter, and consume all remaining arguments.
+	lastIsSlice := paramCount > 0 && methodType.In(paramCount).Kind() == reflect.Slice
+	if len(args) < paramCount && len(fm.defaultArgs) == paramCount {
+		args = append(args, fm.defaultArgs[len(args):]...)
+	}
+	if len(args) != paramCount && !(lastIsSlice && len(args) >= paramCount) {
+		return "", fmt.Errorf("gofakeit method %s expects %d arguments after the seed, got %d. Signature: %s", method, paramCount, len(args), fakerMethodSignature(method, fm))
+	}
+
+	in := []reflect.Value{reflect.ValueOf(faker)}
+	for i := 0; i < paramCount; i++ {
+		paramType := methodType.In(i + 1)
+		if lastIsSlice && i == paramCount-1 {
+			slice := reflect.MakeSlice(paramType, 0, len(args)-i)
+			for _, arg := range args[i:] {
+				elem, err := convertFakerArg(arg, paramType.Elem())
+				if err != nil {
+					return "", err
+				}
+				slice = reflect.Append(slice, elem)
+			}
+			in = append(in, slice)
+			continue
+		}
+		arg, err := convertFakerArg(args[i], paramType)
+		if err != nil {
+			return "", fmt.Errorf("invalid argument %d for gofakeit method %s: %w", i+1, method, err)
+		}
+		in = append(in, arg)
+	}
+
+	out := fm.method.Func.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return "", out[1].Interface().(error)
+	}
+	return formatFakerResult(out[0])
+}
+
+// Describes how to call a gofakeit method, ex: price(seed, float64, float64)
+func fakerMethodSignature(name string, fm fakerMethod) string {
+	params := []string{"seed"}
+	for i := 1; i < fm.method.Type.NumIn(); i++ {
+		paramType := fm.method.Type.In(i)
+		if paramType.Kind() == reflect.Slice {
+			params = append(params, paramType.Elem().String()+"...")
+		} else {
+			params = append(params, paramType.String())
+		}
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(params, ", "))
+}
+
+// Lists the signatures of all gofakeit methods that can be used as valueFuncs.
+// Methods with the same name as a ripoff valueFunc are left out, since they can't be called.
+func FakerValueFuncs() []string {
+	signatures := []string{}
+	for name, fm := range fakerMethods {
+		if slices.Contains(builtinValueFuncs, name) || slices.Contains(structuredValueFuncs, name) {
+			continue
+		}
+		signatures = append(signatures, fakerMethodSignature(name, fm))
+	}
+	slices.Sort(signatures)
+	return signatures
 }
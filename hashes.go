@@ -0,0 +1,159 @@
+package ripoff
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/blowfish"
+)
+
+// bcrypt uses a non-standard base64 alphabet, without padding.
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// "OrpheanBeholderScryDoubt", which bcrypt encrypts with the password and salt.
+var bcryptMagicCipherData = []byte("OrpheanBeholderScryDoubt")
+
+// Splits a password from the arguments after it. Passwords can be quoted to include commas, ex: bcrypt("pa,ss", 12)
+func passwordArgs(value string, valueParts []string) (string, []string, error) {
+	if valueParts[0] == "" || valueParts[0][0] != '"' && valueParts[0][0] != '\'' {
+		return valueParts[0], valueParts[1:], nil
+	}
+	value = strings.TrimSpace(value)
+	quote := value[0]
+	end := strings.IndexByte(value[1:], quote)
+	if end == -1 {
+		return "", nil, fmt.Errorf("password %s is missing a closing quote", value)
+	}
+	password := value[1 : end+1]
+	rest := strings.TrimSpace(value[end+2:])
+	if rest == "" {
+		return password, nil, nil
+	}
+	if rest[0] != ',' {
+		return "", nil, fmt.Errorf("expected a comma after the quoted password, got %s", rest)
+	}
+	args := strings.Split(rest[1:], ",")
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+	return password, args, nil
+}
+
+// Hashes a password with bcrypt, using a salt read from r.
+// golang.org/x/crypto/bcrypt always uses a random salt, so this follows its implementation with a seeded salt instead.
+func bcryptHash(r *rand.Rand, password string, cost int) (string, error) {
+	if len(password) > 72 {
+		return "", fmt.Errorf("bcrypt passwords cannot be longer than 72 bytes")
+	}
+	if cost < 4 || cost > 31 {
+		return "", fmt.Errorf("bcrypt cost must be between 4 and 31, got %d", cost)
+	}
+	salt := make([]byte, 16)
+	_, err := r.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	// Like C implementations, the trailing NULL of the password is used as part of the key.
+	key := append([]byte(password), 0)
+	cipher, err := blowfish.NewSaltedCipher(key, salt)
+	if err != nil {
+		return "", err
+	}
+	for i := uint64(0); i < 1<<cost; i++ {
+		blowfish.ExpandKey(key, cipher)
+		blowfish.ExpandKey(salt, cipher)
+	}
+	cipherData := make([]byte, len(bcryptMagicCipherData))
+	copy(cipherData, bcryptMagicCipherData)
+	for i := 0; i < len(cipherData); i += 8 {
+		for j := 0; j < 64; j++ {
+			cipher.Encrypt(cipherData[i:i+8], cipherData[i:i+8])
+		}
+	}
+	// Also like C implementations, only 23 of the 24 bytes are encoded.
+	return fmt.Sprintf("$2a$%02d$%s%s", cost, bcryptEncoding.EncodeToString(salt), bcryptEncoding.EncodeToString(cipherData[:23])), nil
+}
+
+// Hashes a password with argon2id, using a salt read from r. Returns the hash in PHC string format.
+// Args are in the format: [memory in KiB], [iterations], [parallelism]
+func argon2idHash(r *rand.Rand, password string, args []string) (string, error) {
+	// The minimum recommended by OWASP, since generating many hashes with more memory is slow.
+	params := []uint64{19 * 1024, 2, 1}
+	if len(args) > len(params) {
+		return "", fmt.Errorf("argon2id accepts at most memory, iterations, and parallelism, ex: argon2id(password, 65536, 3, 4). Quote passwords that contain commas")
+	}
+	for i, arg := range args {
+		param, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return "", err
+		}
+		if param == 0 {
+			return "", fmt.Errorf("argon2id parameters must be positive")
+		}
+		params[i] = param
+	}
+	if params[2] > 255 {
+		return "", fmt.Errorf("argon2id parallelism must be at most 255, got %d", params[2])
+	}
+	salt := make([]byte, 16)
+	_, err := r.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	memory, iterations, parallelism := uint32(params[0]), uint32(params[1]), uint8(params[2])
+	hash := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, 32)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		memory,
+		iterations,
+		parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	), nil
+}
+
+func sha256Hex(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacHex(key string, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Generates an API token with a prefix followed by random base62 characters. Args are in the format: [prefix], [length]
+func apiToken(r *rand.Rand, args []string) (string, error) {
+	prefix := ""
+	length := 32
+	if len(args) > 2 {
+		return "", fmt.Errorf("apiToken accepts at most a prefix and length, ex: apiToken(seed, sk_live_, 32)")
+	}
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+	if len(args) > 1 {
+		var err error
+		length, err = strconv.Atoi(args[1])
+		if err != nil {
+			return "", err
+		}
+	}
+	if length <= 0 {
+		return "", fmt.Errorf("apiToken length must be positive, got %d", length)
+	}
+	token := make([]byte, length)
+	for i := range token {
+		token[i] = base62Alphabet[r.Intn(len(base62Alphabet))]
+	}
+	return prefix + string(token), nil
+}
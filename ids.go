@@ -0,0 +1,134 @@
+package ripoff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Like uuidv7, timestamps in IDs are randomly generated in the past so that new inserts appear first when sorting.
+const goReleaseDateMilli = goReleaseDateNano / nanoPerMilli
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Generates a ULID, which is a 48 bit millisecond timestamp and 80 random bits in Crockford's base32.
+func newULID(r *rand.Rand) (string, error) {
+	id := make([]byte, 16)
+	milli := uint64(r.Int63() % goReleaseDateMilli)
+	// The timestamp is big endian, in the first six bytes.
+	binary.BigEndian.PutUint64(id[0:8], milli<<16)
+	_, err := r.Read(id[6:])
+	if err != nil {
+		return "", err
+	}
+	// 128 bits are encoded as 26 characters of 5 bits each, with the first character only using 3 bits.
+	value := new(big.Int).SetBytes(id)
+	encoded := make([]byte, 26)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockfordAlphabet[value.Uint64()&31]
+		value.Rsh(value, 5)
+	}
+	return string(encoded), nil
+}
+
+// KSUID timestamps are seconds since 2014-05-13, the "KSUID epoch".
+const ksuidEpoch = 1400000000
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Generates a KSUID, which is a 32 bit timestamp and 128 random bits in base62.
+// Since KSUIDs can't be older than their epoch, timestamps are within a year of it.
+func newKSUID(r *rand.Rand) (string, error) {
+	id := make([]byte, 20)
+	binary.BigEndian.PutUint32(id[0:4], uint32(r.Int63n(365*24*60*60)))
+	_, err := r.Read(id[4:])
+	if err != nil {
+		return "", err
+	}
+	encoded := new(big.Int).SetBytes(id).Text(62)
+	// KSUIDs are always 27 characters, padded with zeros.
+	return strings.Repeat("0", 27-len(encoded)) + encoded, nil
+}
+
+const nanoidAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Generates a nanoid. Args are in the format: [size], [alphabet]
+func newNanoid(r *rand.Rand, args []string) (string, error) {
+	size := 21
+	alphabet := []rune(nanoidAlphabet)
+	if len(args) > 2 {
+		return "", fmt.Errorf("nanoid accepts at most a size and alphabet, ex: nanoid(seed, 10, abcdef)")
+	}
+	if len(args) > 0 {
+		var err error
+		size, err = strconv.Atoi(args[0])
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(args) > 1 {
+		alphabet = []rune(args[1])
+	}
+	if size <= 0 || len(alphabet) == 0 {
+		return "", fmt.Errorf("nanoid requires a positive size and a non-empty alphabet")
+	}
+	id := make([]rune, size)
+	for i := range id {
+		id[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(id), nil
+}
+
+// Twitter's snowflake epoch, 2010-11-04, in milliseconds.
+const snowflakeEpochMilli = 1288834974657
+
+// Generates a snowflake ID, which is a 41 bit millisecond timestamp, 10 bit machine ID, and 12 bit sequence.
+// Args are in the format: [machineId]
+func newSnowflake(r *rand.Rand, args []string) (string, error) {
+	milli := r.Int63n(goReleaseDateMilli - snowflakeEpochMilli)
+	machineId := r.Int63n(1 << 10)
+	if len(args) > 1 {
+		return "", fmt.Errorf("snowflake accepts at most a machine ID, ex: snowflake(seed, 12)")
+	}
+	if len(args) > 0 {
+		var err error
+		machineId, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return "", err
+		}
+		if machineId < 0 || machineId >= 1<<10 {
+			return "", fmt.Errorf("snowflake machine ID must be between 0 and 1023, got %d", machineId)
+		}
+	}
+	sequence := r.Int63n(1 << 12)
+	return strconv.FormatInt(milli<<22|machineId<<12|sequence, 10), nil
+}
+
+var uuidNamespaces = map[string]uuid.UUID{
+	"dns":  uuid.NameSpaceDNS,
+	"url":  uuid.NameSpaceURL,
+	"oid":  uuid.NameSpaceOID,
+	"x500": uuid.NameSpaceX500,
+}
+
+// Generates a v5 UUID, which is a SHA-1 hash of a namespace and name.
+// The namespace is either dns, url, oid, x500, or a UUID.
+func newUUIDv5(namespace string, name string) (string, error) {
+	namespaceUUID, isNamedNamespace := uuidNamespaces[strings.ToLower(namespace)]
+	if !isNamedNamespace {
+		var err error
+		namespaceUUID, err = uuid.Parse(namespace)
+		if err != nil {
+			return "", fmt.Errorf("uuidv5 namespace must be dns, url, oid, x500, or a UUID, got %s", namespace)
+		}
+	}
+	if name == "" {
+		return "", fmt.Errorf("uuidv5 requires a namespace and name, ex: uuidv5(dns, example.com)")
+	}
+	return uuid.NewSHA1(namespaceUUID, []byte(name)).String(), nil
+}
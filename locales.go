@@ -0,0 +1,160 @@
+package ripoff
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Locale dictionaries are embedded so that generation stays offline and deterministic.
+var locales = mustLoadLocales()
+
+type localeCity struct {
+	City  string `json:"city"`
+	State string `json:"state"`
+}
+
+// Dictionaries and formats used to generate fake data for a locale.
+// In formats, "#" is replaced with a random digit.
+type localeData struct {
+	Country              string            `json:"country"`
+	DomainSuffix         string            `json:"domainSuffix"`
+	NameFormat           string            `json:"nameFormat"`
+	PhoneFormat          string            `json:"phoneFormat"`
+	PhoneFormattedFormat string            `json:"phoneFormattedFormat"`
+	ZipFormat            string            `json:"zipFormat"`
+	BuildingNumberFormat string            `json:"buildingNumberFormat"`
+	StreetFormat         string            `json:"streetFormat"`
+	AddressFormat        string            `json:"addressFormat"`
+	Transliterations     map[string]string `json:"transliterations"`
+	Romanizations        map[string]string `json:"romanizations"`
+	FirstNames           []string          `json:"firstNames"`
+	LastNames            []string          `json:"lastNames"`
+	Streets              []string          `json:"streets"`
+	Cities               []localeCity      `json:"cities"`
+}
+
+func mustLoadLocales() map[string]*localeData {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	loaded := map[string]*localeData{}
+	for _, entry := range entries {
+		contents, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		data := &localeData{}
+		err = json.Unmarshal(contents, data)
+		if err != nil {
+			panic(fmt.Errorf("invalid locale file %s: %w", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = data
+	}
+	return loaded
+}
+
+// Returns the data for a locale, or nil for the default locale (English), which gofakeit provides.
+func getLocale(name string) (*localeData, error) {
+	if name == "" || name == "en" {
+		return nil, nil
+	}
+	data, hasLocale := locales[name]
+	if !hasLocale {
+		return nil, fmt.Errorf("unknown locale %s", name)
+	}
+	return data, nil
+}
+
+// Converts a localized value to ASCII where possible, for use in emails and usernames.
+func (l *localeData) romanize(value string) string {
+	if l == nil {
+		return value
+	}
+	romanized, hasRomanization := l.Romanizations[value]
+	if hasRomanization {
+		return romanized
+	}
+	value = strings.ToLower(value)
+	for from, to := range l.Transliterations {
+		value = strings.ReplaceAll(value, from, to)
+	}
+	return value
+}
+
+// Replaces "#" with random digits. Unlike gofakeit.Numerify, leading zeros are kept, which phone formats need.
+func numerify(faker *gofakeit.Faker, format string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '#' {
+			return rune('0' + faker.IntN(10))
+		}
+		return r
+	}, format)
+}
+
+func (l *localeData) name(first string, last string) string {
+	return strings.NewReplacer("{first}", first, "{last}", last).Replace(l.NameFormat)
+}
+
+// Generates an address where the city, state, and zip all come from the locale.
+func (l *localeData) address(faker *gofakeit.Faker) *gofakeit.AddressInfo {
+	city := l.Cities[faker.IntN(len(l.Cities))]
+	street := strings.NewReplacer(
+		"{street}", l.Streets[faker.IntN(len(l.Streets))],
+		"{number}", numerify(faker, l.BuildingNumberFormat),
+	).Replace(l.StreetFormat)
+	zip := numerify(faker, l.ZipFormat)
+	return &gofakeit.AddressInfo{
+		Address: strings.NewReplacer(
+			"{street}", street,
+			"{zip}", zip,
+			"{city}", city.City,
+			"{state}", city.State,
+		).Replace(l.AddressFormat),
+		Street:    street,
+		City:      city.City,
+		State:     city.State,
+		Zip:       zip,
+		Country:   l.Country,
+		Latitude:  faker.Latitude(),
+		Longitude: faker.Longitude(),
+	}
+}
+
+// Calls a localized version of a gofakeit method, returning false if the method isn't localized.
+func (l *localeData) callFakerMethod(method string, faker *gofakeit.Faker) (string, bool) {
+	switch method {
+	case "firstName":
+		return l.FirstNames[faker.IntN(len(l.FirstNames))], true
+	case "lastName":
+		return l.LastNames[faker.IntN(len(l.LastNames))], true
+	case "name":
+		first := l.FirstNames[faker.IntN(len(l.FirstNames))]
+		return l.name(first, l.LastNames[faker.IntN(len(l.LastNames))]), true
+	case "phone":
+		return numerify(faker, l.PhoneFormat), true
+	case "phoneFormatted":
+		return numerify(faker, l.PhoneFormattedFormat), true
+	case "zip":
+		return numerify(faker, l.ZipFormat), true
+	case "country":
+		return l.Country, true
+	case "city":
+		return l.Cities[faker.IntN(len(l.Cities))].City, true
+	case "state":
+		return l.Cities[faker.IntN(len(l.Cities))].State, true
+	case "streetName":
+		return l.Streets[faker.IntN(len(l.Streets))], true
+	case "street":
+		return l.address(faker).Street, true
+	}
+	return "", false
+}
@@ -0,0 +1,60 @@
+package ripoff
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// Returns everything after the seed as a single argument, since formats may contain commas.
+// Surrounding quotes are removed, ex: pattern(seed, "INV-####") => INV-####
+func rawArgument(value string) string {
+	_, argument, _ := strings.Cut(value, ",")
+	argument = strings.TrimSpace(argument)
+	if len(argument) >= 2 && (argument[0] == '"' || argument[0] == '\'') && argument[len(argument)-1] == argument[0] {
+		argument = argument[1 : len(argument)-1]
+	}
+	return argument
+}
+
+// Generates a string matching a regular expression, ex: [A-Z]{3}-\d{4}
+func randomRegex(faker *gofakeit.Faker, pattern string) (string, error) {
+	if pattern == "" {
+		return "", fmt.Errorf(`regex requires a pattern, ex: regex(seed, [A-Z]{3}-\d{4})`)
+	}
+	// gofakeit returns an error message instead of an error, so validate the pattern first.
+	_, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("invalid regex %s: %w", pattern, err)
+	}
+	return faker.Regex(pattern), nil
+}
+
+// Generates a string from a simple format, where "#" is a digit and "?" is an upper case letter.
+// Any character can be escaped with a backslash, ex: \# is a literal "#".
+func randomPattern(r *randv2.Rand, format string) (string, error) {
+	if format == "" {
+		return "", fmt.Errorf(`pattern requires a format, ex: pattern(seed, "INV-####-??")`)
+	}
+	result := strings.Builder{}
+	escaped := false
+	for _, char := range format {
+		switch {
+		case escaped:
+			result.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '#':
+			result.WriteRune(rune('0' + r.IntN(10)))
+		case char == '?':
+			result.WriteRune(rune('A' + r.IntN(26)))
+		default:
+			result.WriteRune(char)
+		}
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,118 @@
+package ripoff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+// valueFuncs that return structured values, which can be accessed with a field path like person(seed).address.city
+var structuredValueFuncs = []string{"person", "address", "latlng"}
+
+var fieldPathRegex = regexp.MustCompile(`^(.*\))\.([a-zA-Z0-9_.]+)$`)
+
+// A gofakeit.PersonInfo with extra fields derived from the person's name, so that related columns agree.
+type persona struct {
+	*gofakeit.PersonInfo
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Phone    string `json:"phone"`
+}
+
+// Splits a raw value like person(seed).address.city into person(seed) and address.city.
+func splitFieldPath(rawValue string) (string, string) {
+	fieldPathMatches := fieldPathRegex.FindStringSubmatch(rawValue)
+	if len(fieldPathMatches) != 3 {
+		return rawValue, ""
+	}
+	valueFuncMatches := valueFuncRegex.FindStringSubmatch(fieldPathMatches[1])
+	if len(valueFuncMatches) != 3 || !slices.Contains(structuredValueFuncs, valueFuncMatches[1]) {
+		return rawValue, ""
+	}
+	return fieldPathMatches[1], fieldPathMatches[2]
+}
+
+// Lowercases and strips everything but letters and numbers, for use in emails and usernames.
+func identifierPart(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(value))
+}
+
+func newPersona(faker *gofakeit.Faker, locale *localeData) persona {
+	person := faker.Person()
+	name := person.FirstName + " " + person.LastName
+	domain := faker.DomainName()
+	if locale != nil {
+		person.FirstName = locale.FirstNames[faker.IntN(len(locale.FirstNames))]
+		person.LastName = locale.LastNames[faker.IntN(len(locale.LastNames))]
+		person.Address = locale.address(faker)
+		person.Contact.Phone = numerify(faker, locale.PhoneFormat)
+		name = locale.name(person.FirstName, person.LastName)
+		domain = domain[:strings.LastIndex(domain, ".")+1] + locale.DomainSuffix
+	}
+	first := identifierPart(locale.romanize(person.FirstName))
+	last := identifierPart(locale.romanize(person.LastName))
+	email := fmt.Sprintf("%s.%s@%s", first, last, domain)
+	person.Contact.Email = email
+	return persona{
+		PersonInfo: person,
+		Name:       name,
+		Email:      email,
+		Username:   fmt.Sprintf("%s%s%d", first, last, faker.Number(1, 99)),
+		Phone:      person.Contact.Phone,
+	}
+}
+
+// Finds a field by name, ignoring case and underscores so that both firstName and first_name work.
+func lookupField(value reflect.Value, fieldPath string) (reflect.Value, error) {
+	for _, fieldName := range strings.Split(fieldPath, ".") {
+		for value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("cannot access field %s of a non-struct value", fieldName)
+		}
+		normalizedName := strings.ReplaceAll(fieldName, "_", "")
+		value = value.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, normalizedName)
+		})
+		if !value.IsValid() {
+			return reflect.Value{}, fmt.Errorf("unknown field %s in %s", fieldName, fieldPath)
+		}
+	}
+	return value, nil
+}
+
+// Generates a person or address, returning a single field if a path is provided or JSON otherwise.
+func structuredValue(kind string, faker *gofakeit.Faker, locale *localeData, fieldPath string) (string, error) {
+	var value any
+	switch kind {
+	case "person":
+		value = newPersona(faker, locale)
+	case "address":
+		if locale != nil {
+			value = locale.address(faker)
+		} else {
+			value = faker.Address()
+		}
+	default:
+		return "", fmt.Errorf("%s does not return a structured value", kind)
+	}
+	if fieldPath == "" {
+		return formatFakerResult(reflect.ValueOf(value))
+	}
+	field, err := lookupField(reflect.ValueOf(value), fieldPath)
+	if err != nil {
+		return "", fmt.Errorf("%s(): %w", kind, err)
+	}
+	return formatFakerResult(field)
+}
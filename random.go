@@ -0,0 +1,135 @@
+package ripoff
+
+import (
+	"fmt"
+	"math"
+	randv2 "math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Picks one of the given options with equal probability.
+func randomOneOf(r *randv2.Rand, options []string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("oneOf requires at least one option, ex: oneOf(seed, draft, published)")
+	}
+	return options[r.IntN(len(options))], nil
+}
+
+// Picks one of the given options, which are in the format value:weight.
+func randomWeighted(r *randv2.Rand, options []string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("weighted requires at least one option, ex: weighted(seed, free:80, pro:20)")
+	}
+	values := make([]string, len(options))
+	weights := make([]float64, len(options))
+	for i, option := range options {
+		separatorIndex := strings.LastIndex(option, ":")
+		if separatorIndex == -1 {
+			return "", fmt.Errorf("weighted option %s is missing a weight, ex: free:80", option)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(option[separatorIndex+1:]), 64)
+		if err != nil {
+			return "", err
+		}
+		values[i] = option[:separatorIndex]
+		weights[i] = weight
+	}
+	index, err := weightedIndex(r, weights)
+	if err != nil {
+		return "", err
+	}
+	return values[index], nil
+}
+
+// Picks an index based on the relative weight of each index.
+func weightedIndex(r *randv2.Rand, weights []float64) (int, error) {
+	totalWeight := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return 0, fmt.Errorf("weights cannot be negative, got %g", weight)
+		}
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return 0, fmt.Errorf("weighted options must have at least one non-zero weight")
+	}
+	target := r.Float64() * totalWeight
+	for i, weight := range weights {
+		target -= weight
+		if target < 0 {
+			return i, nil
+		}
+	}
+	return len(weights) - 1, nil
+}
+
+// Generates a boolean that is true with the given probability, which defaults to 0.5.
+func randomBool(r *randv2.Rand, args []string) (string, error) {
+	probability := 0.5
+	if len(args) > 0 {
+		var err error
+		probability, err = strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return "", err
+		}
+		if probability < 0 || probability > 1 {
+			return "", fmt.Errorf("bool probability must be between 0 and 1, got %g", probability)
+		}
+	}
+	return strconv.FormatBool(r.Float64() < probability), nil
+}
+
+// Generates a float in the half-open range [min, max), optionally rounded to a number of decimals.
+// Args are in the format: [[min], max], [decimals]
+func randomFloat(r *randv2.Rand, args []string) (string, error) {
+	argsFloat := make([]float64, len(args))
+	for i, arg := range args {
+		argFloat, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", err
+		}
+		argsFloat[i] = argFloat
+	}
+	min := 0.0
+	max := 1.0
+	decimals := 0
+	isRounded := false
+	switch len(argsFloat) {
+	case 0:
+	case 1:
+		max = argsFloat[0]
+	case 2:
+		min, max = argsFloat[0], argsFloat[1]
+	case 3:
+		min, max = argsFloat[0], argsFloat[1]
+		decimals = int(argsFloat[2])
+		isRounded = true
+	default:
+		return "", fmt.Errorf("float accepts at most a min, max, and decimals, ex: float(seed, 0.5, 99.99, 2)")
+	}
+	if max <= min {
+		return "", fmt.Errorf("float range is empty: %g to %g", min, max)
+	}
+	if !isRounded {
+		return strconv.FormatFloat(min+r.Float64()*(max-min), 'f', -1, 64), nil
+	}
+	if decimals < 0 {
+		return "", fmt.Errorf("float decimals cannot be negative, got %d", decimals)
+	}
+	// Rounding a random float could land on max, so instead pick one of the rounded values in the range.
+	scale := math.Pow10(decimals)
+	lowest := math.Floor(min * scale)
+	if lowest/scale < min {
+		lowest++
+	}
+	highest := math.Ceil(max * scale)
+	if (highest-1)/scale >= max {
+		highest--
+	}
+	if highest <= lowest {
+		return "", fmt.Errorf("float range %g to %g has no values with %d decimals", min, max, decimals)
+	}
+	step := math.Floor(r.Float64() * (highest - lowest))
+	return strconv.FormatFloat((lowest+step)/scale, 'f', decimals, 64), nil
+}
@@ -2,8 +2,10 @@ package ripoff
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -23,6 +25,17 @@ type RipoffPlugin struct {
 type RipoffFile struct {
 	Plugins map[string]RipoffPlugin `yaml:"plugins"`
 	Rows    map[string]Row          `yaml:"rows"`
+	// The locale for fake data, ex: de or ja. Defaults to English.
+	Locale string `yaml:"locale,omitempty"`
+	// Mixed into every valueFunc seed, to generate an alternate dataset.
+	Seed string `yaml:"seed,omitempty"`
+	// Lists of domain specific values, for use with fromList() and in templates.
+	Dictionaries map[string]Dictionary `yaml:"dictionaries,omitempty"`
+	// The directory the ripoff was loaded from.
+	Dir string `yaml:"-"`
+	// The directory of the file that defined each row, which file() paths are relative to.
+	// Rows created by templates use the template's directory.
+	RowDirs map[string]string `yaml:"-"`
 }
 
 var funcMap = template.FuncMap{
@@ -38,10 +51,101 @@ var funcMap = template.FuncMap{
 
 var templateFileRegex = regexp.MustCompile(`^template_(\S+)\.`)
 
-// Adds newRows to existingRows, processing templated rows when needed.
-func concatRows(templates *template.Template, existingRows map[string]Row, newRows map[string]Row, enums EnumValuesResult) error {
+// Templates in a ripoff directory, named by their path relative to the directory, ex: billing/template_invoice.yml
+// Templates can also be used by their filename, as long as no other template has the same filename.
+type ripoffTemplates struct {
+	*template.Template
+	// Paths for filenames that are used by more than one template.
+	ambiguous map[string][]string
+	// Params declared in each template's front-matter, if any.
+	params map[string]map[string]TemplateParam
+	// The directory of each template, which file() paths in rows it creates are relative to.
+	dirs map[string]string
+	// Backs the fake template function, which needs to know the row rendering a template.
+	valueFuncs *templateValueFuncs
+}
+
+// Parses templates found anywhere in dir.
+func parseTemplates(templates *template.Template, dir string, templatePaths []string) (ripoffTemplates, error) {
+	namesByFilename := map[string][]string{}
+	params := map[string]map[string]TemplateParam{}
+	dirs := map[string]string{}
+	for _, templatePath := range templatePaths {
+		relativePath, err := filepath.Rel(dir, templatePath)
+		if err != nil {
+			return ripoffTemplates{}, err
+		}
+		name := filepath.ToSlash(relativePath)
+		contents, err := os.ReadFile(templatePath)
+		if err != nil {
+			return ripoffTemplates{}, err
+		}
+		header, body, hasHeader := splitFrontMatter(string(contents))
+		if hasHeader {
+			params[name], err = parseTemplateParams(header)
+			if err != nil {
+				return ripoffTemplates{}, fmt.Errorf("template %s: %w", name, err)
+			}
+		}
+		_, err = templates.New(name).Parse(body)
+		if err != nil {
+			return ripoffTemplates{}, err
+		}
+		namesByFilename[path.Base(name)] = append(namesByFilename[path.Base(name)], name)
+		dirs[name] = filepath.Dir(templatePath)
+	}
+	ambiguous := map[string][]string{}
+	for filename, filenameNames := range namesByFilename {
+		// Templates in dir are already named by their filename.
+		if templates.Lookup(filename) != nil {
+			continue
+		}
+		if len(filenameNames) > 1 {
+			ambiguous[filename] = filenameNames
+			continue
+		}
+		_, err := templates.AddParseTree(filename, templates.Lookup(filenameNames[0]).Tree)
+		if err != nil {
+			return ripoffTemplates{}, err
+		}
+		filenameParams, hasParams := params[filenameNames[0]]
+		if hasParams {
+			params[filename] = filenameParams
+		}
+		dirs[filename] = dirs[filenameNames[0]]
+	}
+	return ripoffTemplates{Template: templates, ambiguous: ambiguous, params: params, dirs: dirs}, nil
+}
+
+// Renders a template by its relative path or filename, validating the calling row against the template's params.
+// Returns the template's directory.
+func (t ripoffTemplates) execute(buf *bytes.Buffer, rowId string, name string, templateVars Row) (string, error) {
+	name = path.Clean(filepath.ToSlash(name))
+	paths, isAmbiguous := t.ambiguous[name]
+	if isAmbiguous {
+		return "", fmt.Errorf("template %s is ambiguous, use one of: %s", name, strings.Join(paths, ", "))
+	}
+	if t.Lookup(name) == nil {
+		return "", fmt.Errorf("template %s does not exist, template paths are relative to the ripoff directory", name)
+	}
+	params, hasParams := t.params[name]
+	if hasParams {
+		err := applyTemplateParams(params, rowId, name, templateVars)
+		if err != nil {
+			return "", err
+		}
+	}
+	if t.valueFuncs != nil {
+		t.valueFuncs.row = templateVars
+		t.valueFuncs.dir = t.dirs[name]
+	}
+	return t.dirs[name], t.ExecuteTemplate(buf, name, templateVars)
+}
+
+// Adds newRows, which were defined in dir, to totalRipoff, processing templated rows when needed.
+func concatRows(templates ripoffTemplates, totalRipoff *RipoffFile, newRows map[string]Row, dir string, enums EnumValuesResult, dictionaries map[string][]string) error {
 	for rowId, row := range newRows {
-		_, rowExists := existingRows[rowId]
+		_, rowExists := totalRipoff.Rows[rowId]
 		if rowExists {
 			return fmt.Errorf("row %s is defined more than once", rowId)
 		}
@@ -52,8 +156,9 @@ func concatRows(templates *template.Template, existingRows map[string]Row, newRo
 			templateVars := row
 			templateVars["rowId"] = rowId
 			templateVars["enums"] = enums
+			templateVars["dictionaries"] = dictionaries
 			buf := &bytes.Buffer{}
-			err := templates.ExecuteTemplate(buf, templateName, templateVars)
+			templateDir, err := templates.execute(buf, rowId, templateName, templateVars)
 			if err != nil {
 				return err
 			}
@@ -62,40 +167,110 @@ func concatRows(templates *template.Template, existingRows map[string]Row, newRo
 			if err != nil {
 				return err
 			}
-			err = concatRows(templates, existingRows, ripoff.Rows, enums)
+			err = concatRows(templates, totalRipoff, ripoff.Rows, templateDir, enums, dictionaries)
 			if err != nil {
 				return err
 			}
 		} else {
-			existingRows[rowId] = row
+			totalRipoff.Rows[rowId] = row
+			totalRipoff.RowDirs[rowId] = dir
 		}
 	}
 	return nil
 }
 
+// Renders ripoff files ending in .tmpl.yml or .tmpl.yaml once, with the same global variables as templates.
+func renderRipoffFiles(templates ripoffTemplates, dir string, renderPaths []string, enums EnumValuesResult, dictionaries map[string][]string) ([]RipoffFile, error) {
+	// Every file is parsed before any are rendered, since templates can't be cloned after rendering.
+	fileTemplates := []*template.Template{}
+	for _, renderPath := range renderPaths {
+		relativePath, err := filepath.Rel(dir, renderPath)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.ToSlash(relativePath)
+		contents, err := os.ReadFile(renderPath)
+		if err != nil {
+			return nil, err
+		}
+		// Cloned so that rows can't use rendered files as templates.
+		fileTemplate, err := templates.Clone()
+		if err != nil {
+			return nil, err
+		}
+		fileTemplate, err = fileTemplate.New(name).Parse(string(contents))
+		if err != nil {
+			return nil, err
+		}
+		fileTemplates = append(fileTemplates, fileTemplate)
+	}
+	ripoffs := []RipoffFile{}
+	for i, fileTemplate := range fileTemplates {
+		fileDir := filepath.Dir(renderPaths[i])
+		buf := &bytes.Buffer{}
+		// Rendered files aren't called by a row, so only the file's locale applies.
+		if templates.valueFuncs != nil {
+			templates.valueFuncs.row = nil
+			templates.valueFuncs.dir = fileDir
+		}
+		err := fileTemplate.Execute(buf, Row{"enums": enums, "dictionaries": dictionaries})
+		if err != nil {
+			return nil, err
+		}
+		ripoff := RipoffFile{}
+		err = yaml.Unmarshal(buf.Bytes(), &ripoff)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileTemplate.Name(), err)
+		}
+		if len(ripoff.Plugins) > 0 || ripoff.Locale != "" || ripoff.Seed != "" || len(ripoff.Dictionaries) > 0 {
+			return nil, fmt.Errorf("%s can only define rows, since settings are loaded before it is rendered", fileTemplate.Name())
+		}
+		ripoff.Dir = fileDir
+		ripoffs = append(ripoffs, ripoff)
+	}
+	return ripoffs, nil
+}
+
+// Options for loading ripoffs that can't be set in ripoff files, ex: command line flags.
+type RipoffOptions struct {
+	// Overrides the seed set in ripoff files.
+	Seed string
+	// Used to start plugins called by templates. Defaults to context.Background().
+	Context context.Context
+	// Called before templates start plugins, ex: to confirm that plugin commands are safe to run.
+	ConfirmPlugins func(plugins map[string]RipoffPlugin) error
+}
+
 // Builds a single RipoffFile from a directory of yaml files.
 func RipoffFromDirectory(dir string, enums EnumValuesResult) (RipoffFile, error) {
-	dir = filepath.Clean(dir)
+	return RipoffFromDirectoryWithOptions(dir, enums, RipoffOptions{})
+}
 
-	// Treat files starting with template_ as go templates.
-	templates := template.New("").Option("missingkey=error").Funcs(funcMap)
-	_, err := templates.ParseGlob(filepath.Join(dir, "template_*"))
-	if err != nil && !strings.Contains(err.Error(), "template: pattern matches no files") {
-		return RipoffFile{}, err
-	}
+// Builds a single RipoffFile from a directory of yaml files, with options that override ripoff files.
+func RipoffFromDirectoryWithOptions(dir string, enums EnumValuesResult, options RipoffOptions) (RipoffFile, error) {
+	dir = filepath.Clean(dir)
 
-	// Find all ripoff files in dir recursively.
+	// Find all ripoff files and templates in dir recursively.
 	allRipoffs := []RipoffFile{}
-	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+	templatePaths := []string{}
+	renderPaths := []string{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml" {
+		// Other files, ex: ones loaded by file(), are ignored even if they start with template_.
+		if entry.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
 			return nil
 		}
-		// Templates were already processed.
+		// Treat files starting with template_ as go templates, which are parsed once settings are known.
 		templateNameMatches := templateFileRegex.FindStringSubmatch(entry.Name())
 		if len(templateNameMatches) == 2 {
+			templatePaths = append(templatePaths, path)
+			return nil
+		}
+		// Ripoff files ending in .tmpl.yml are rendered once templates are parsed.
+		if strings.HasSuffix(path, ".tmpl.yml") || strings.HasSuffix(path, ".tmpl.yaml") {
+			renderPaths = append(renderPaths, path)
 			return nil
 		}
 
@@ -108,6 +283,14 @@ func RipoffFromDirectory(dir string, enums EnumValuesResult) (RipoffFile, error)
 		if err != nil {
 			return err
 		}
+		for name, dictionary := range ripoff.Dictionaries {
+			err = dictionary.load(filepath.Dir(path))
+			if err != nil {
+				return fmt.Errorf("could not load dictionary %s: %w", name, err)
+			}
+			ripoff.Dictionaries[name] = dictionary
+		}
+		ripoff.Dir = filepath.Dir(path)
 		allRipoffs = append(allRipoffs, *ripoff)
 		return nil
 	})
@@ -117,15 +300,70 @@ func RipoffFromDirectory(dir string, enums EnumValuesResult) (RipoffFile, error)
 	}
 
 	totalRipoff := RipoffFile{
-		Rows:    map[string]Row{},
-		Plugins: map[string]RipoffPlugin{},
+		Rows:         map[string]Row{},
+		Plugins:      map[string]RipoffPlugin{},
+		Dictionaries: map[string]Dictionary{},
+		Dir:          dir,
+		RowDirs:      map[string]string{},
 	}
 
+	// Settings are merged first, so that templates in any file can use them.
 	for _, ripoff := range allRipoffs {
 		for k, v := range ripoff.Plugins {
 			totalRipoff.Plugins[k] = v
 		}
-		err = concatRows(templates, totalRipoff.Rows, ripoff.Rows, enums)
+		if ripoff.Locale != "" {
+			if totalRipoff.Locale != "" && totalRipoff.Locale != ripoff.Locale {
+				return RipoffFile{}, fmt.Errorf("locale is set to both %s and %s, use ~locale to set the locale for individual rows", totalRipoff.Locale, ripoff.Locale)
+			}
+			totalRipoff.Locale = ripoff.Locale
+		}
+		if ripoff.Seed != "" {
+			if totalRipoff.Seed != "" && totalRipoff.Seed != ripoff.Seed {
+				return RipoffFile{}, fmt.Errorf("seed is set to both %s and %s", totalRipoff.Seed, ripoff.Seed)
+			}
+			totalRipoff.Seed = ripoff.Seed
+		}
+		for name, dictionary := range ripoff.Dictionaries {
+			_, dictionaryExists := totalRipoff.Dictionaries[name]
+			if dictionaryExists {
+				return RipoffFile{}, fmt.Errorf("dictionary %s is defined more than once", name)
+			}
+			totalRipoff.Dictionaries[name] = dictionary
+		}
+	}
+	dictionaries := dictionaryValues(totalRipoff.Dictionaries)
+	if options.Seed != "" {
+		totalRipoff.Seed = options.Seed
+	}
+
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Stops any plugins that templates started.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	valueFuncs := newTemplateValueFuncs(ctx, totalRipoff, enums, options)
+	defer valueFuncs.close()
+
+	templates, err := parseTemplates(template.New("").Option("missingkey=error").
+		Funcs(funcMap).
+		Funcs(helperFuncMap).
+		Funcs(seededFuncMap(totalRipoff.Seed)).
+		Funcs(template.FuncMap{"fake": valueFuncs.fake}), dir, templatePaths)
+	if err != nil {
+		return RipoffFile{}, err
+	}
+	templates.valueFuncs = valueFuncs
+
+	renderedRipoffs, err := renderRipoffFiles(templates, dir, renderPaths, enums, dictionaries)
+	if err != nil {
+		return RipoffFile{}, err
+	}
+
+	for _, ripoff := range append(allRipoffs, renderedRipoffs...) {
+		err = concatRows(templates, &totalRipoff, ripoff.Rows, ripoff.Dir, enums, dictionaries)
 		if err != nil {
 			return RipoffFile{}, err
 		}
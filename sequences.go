@@ -0,0 +1,97 @@
+package ripoff
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Compares strings so that runs of digits are ordered numerically, ex: users:uuid(user-2) < users:uuid(user-10)
+func naturalCompare(a string, b string) int {
+	for a != "" && b != "" {
+		aDigits := len(a) - len(strings.TrimLeftFunc(a, unicode.IsDigit))
+		bDigits := len(b) - len(strings.TrimLeftFunc(b, unicode.IsDigit))
+		if aDigits > 0 && bDigits > 0 {
+			aNumber := strings.TrimLeft(a[:aDigits], "0")
+			bNumber := strings.TrimLeft(b[:bDigits], "0")
+			if len(aNumber) != len(bNumber) {
+				return len(aNumber) - len(bNumber)
+			}
+			if aNumber != bNumber {
+				return strings.Compare(aNumber, bNumber)
+			}
+			a, b = a[aDigits:], b[bDigits:]
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+// Numbers every use of seq(counter) in order of row id, then column, so that sequences are stable across runs.
+// Returns a map of <rowId>.<column> to the zero-based position in its counter.
+func buildSequences(rows map[string]Row) map[string]int {
+	rowIds := []string{}
+	for rowId := range rows {
+		rowIds = append(rowIds, rowId)
+	}
+	slices.SortFunc(rowIds, naturalCompare)
+
+	counters := map[string]int{}
+	positions := map[string]int{}
+	for _, rowId := range rowIds {
+		columns := []string{}
+		for column := range rows[rowId] {
+			columns = append(columns, column)
+		}
+		slices.Sort(columns)
+		for _, column := range columns {
+			valueFuncMatches := valueFuncRegex.FindStringSubmatch(fmt.Sprint(rows[rowId][column]))
+			if len(valueFuncMatches) != 3 || valueFuncMatches[1] != "seq" {
+				continue
+			}
+			counter := sequenceCounter(rowId, column, valueFuncMatches[2])
+			positions[rowId+"."+column] = counters[counter]
+			counters[counter]++
+		}
+	}
+	return positions
+}
+
+// Returns the name of the counter for seq(counter), defaulting to the table and column.
+func sequenceCounter(rowId string, column string, value string) string {
+	counter, _, _ := strings.Cut(value, ",")
+	counter = strings.TrimSpace(counter)
+	if counter == "" {
+		table, _, _ := strings.Cut(rowId, ":")
+		return table + "." + column
+	}
+	return counter
+}
+
+// Returns the next number in a sequence. Args are in the format: [start], [step]
+func sequenceValue(position int, args []string) (string, error) {
+	start, step := 1, 1
+	var err error
+	if len(args) > 2 {
+		return "", fmt.Errorf("seq accepts at most a start and step, ex: seq(invoices, 1000, 10)")
+	}
+	if len(args) > 0 {
+		start, err = strconv.Atoi(args[0])
+		if err != nil {
+			return "", err
+		}
+	}
+	if len(args) > 1 {
+		step, err = strconv.Atoi(args[1])
+		if err != nil {
+			return "", err
+		}
+	}
+	return strconv.Itoa(start + position*step), nil
+}
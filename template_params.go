@@ -0,0 +1,155 @@
+package ripoff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A parameter that a template expects the calling row to pass.
+type TemplateParam struct {
+	// One of: string, int, float, bool, list, map, or any (the default).
+	Type     string      `yaml:"type"`
+	Default  interface{} `yaml:"default"`
+	Required bool        `yaml:"required"`
+	// Shown in errors about this parameter.
+	Description string `yaml:"description"`
+}
+
+// The front-matter of a template, which is a yaml block between "---" lines at the top of the file.
+type templateHeader struct {
+	Params map[string]TemplateParam `yaml:"params"`
+}
+
+var templateParamTypes = []string{"string", "int", "float", "bool", "list", "map", "any"}
+
+// Variables set by ripoff, which calling rows can't pass.
+var builtinTemplateVars = []string{"rowId", "enums", "dictionaries"}
+
+// Splits front-matter from the rest of a template. The front-matter is replaced with blank lines,
+// so that line numbers in template errors still match the file.
+func splitFrontMatter(contents string) (string, string, bool) {
+	lines := strings.SplitAfter(contents, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", contents, false
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			header := strings.Join(lines[1:i], "")
+			body := strings.Repeat("\n", i+1) + strings.Join(lines[i+1:], "")
+			return header, body, true
+		}
+	}
+	return "", contents, false
+}
+
+// Parses a template's front-matter. Templates with front-matter only accept the params they declare.
+func parseTemplateParams(header string) (map[string]TemplateParam, error) {
+	decoder := yaml.NewDecoder(bytes.NewBufferString(header))
+	decoder.KnownFields(true)
+	parsed := templateHeader{}
+	err := decoder.Decode(&parsed)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid front-matter: %w", err)
+	}
+	params := map[string]TemplateParam{}
+	for name, param := range parsed.Params {
+		if slices.Contains(builtinTemplateVars, name) {
+			return nil, fmt.Errorf("param %s is set by ripoff and cannot be declared", name)
+		}
+		if param.Type == "" {
+			param.Type = "any"
+		}
+		if !slices.Contains(templateParamTypes, param.Type) {
+			return nil, fmt.Errorf("param %s has unknown type %s, expected one of: %s", name, param.Type, strings.Join(templateParamTypes, ", "))
+		}
+		if param.Default != nil && !param.accepts(param.Default) {
+			return nil, fmt.Errorf("param %s has a default that is not of type %s", name, param.Type)
+		}
+		params[name] = param
+	}
+	return params, nil
+}
+
+func (p TemplateParam) accepts(value interface{}) bool {
+	switch p.Type {
+	case "string":
+		// Yaml parses unquoted scalars like 123 or true as other types, which are fine as strings.
+		switch value.(type) {
+		case string, int, float64, bool:
+			return true
+		}
+		return false
+	case "int":
+		_, isInt := value.(int)
+		return isInt
+	case "float":
+		switch value.(type) {
+		case int, float64:
+			return true
+		}
+		return false
+	case "bool":
+		_, isBool := value.(bool)
+		return isBool
+	case "list":
+		_, isList := value.([]interface{})
+		return isList
+	case "map":
+		_, isMap := value.(map[string]interface{})
+		return isMap
+	}
+	return true
+}
+
+func (p TemplateParam) describe(name string) string {
+	if p.Description == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, p.Description)
+}
+
+// Validates the variables a row passes to a template, and fills in defaults for missing params.
+// Optional params without a default are set to nil, so that templates can check if they're set.
+func applyTemplateParams(params map[string]TemplateParam, rowId string, templateName string, row Row) error {
+	names := []string{}
+	for name := range params {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	keys := []string{}
+	for key := range row {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := row[key]
+		// Row options like ~dependencies aren't template variables.
+		if key == "template" || strings.HasPrefix(key, "~") || slices.Contains(builtinTemplateVars, key) {
+			continue
+		}
+		param, isParam := params[key]
+		if !isParam {
+			return fmt.Errorf("row %s passes unknown param %s to template %s, expected one of: %s", rowId, key, templateName, strings.Join(names, ", "))
+		}
+		if value != nil && !param.accepts(value) {
+			return fmt.Errorf("row %s passes %v to template %s for param %s, which must be of type %s", rowId, value, templateName, param.describe(key), param.Type)
+		}
+	}
+	for _, name := range names {
+		param := params[name]
+		value, isSet := row[name]
+		if isSet && value != nil {
+			continue
+		}
+		if param.Required {
+			return fmt.Errorf("row %s is missing required param %s for template %s", rowId, param.describe(name), templateName)
+		}
+		row[name] = param.Default
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package ripoff
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	randv2 "math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Hashes a seed for use in random generators, mixing in the global seed if set.
+func seedHash(globalSeed string, value string) [32]byte {
+	if globalSeed == "" {
+		return sha256.Sum256([]byte(value))
+	}
+	return sha256.Sum256([]byte(globalSeed + "\x00" + value))
+}
+
+// Converts a template value, which could come from yaml or another function, to an int.
+func toInt(value any) (int, error) {
+	switch typed := value.(type) {
+	case int:
+		return typed, nil
+	case int64:
+		return int(typed), nil
+	case float64:
+		return int(typed), nil
+	case string:
+		return strconv.Atoi(typed)
+	}
+	return 0, fmt.Errorf("expected a number, got %v", value)
+}
+
+// Converts any slice to []any, so that helpers work with lists from yaml, enums, and other helpers.
+func toList(value any) ([]any, error) {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %v", value)
+	}
+	list := make([]any, reflected.Len())
+	for i := range list {
+		list[i] = reflected.Index(i).Interface()
+	}
+	return list, nil
+}
+
+// Applies an operation to two numbers.
+func arithmetic(operation func(int, int) (int, error)) func(any, any) (int, error) {
+	return func(a any, b any) (int, error) {
+		aInt, err := toInt(a)
+		if err != nil {
+			return 0, err
+		}
+		bInt, err := toInt(b)
+		if err != nil {
+			return 0, err
+		}
+		return operation(aInt, bInt)
+	}
+}
+
+// Splits a string into lowercase words, ex: "fooBar baz_qux" => [foo bar baz qux]
+func words(value string) []string {
+	result := []string{}
+	current := []rune{}
+	runes := []rune(value)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = []rune{}
+			}
+			continue
+		}
+		// Start a new word at camelCase boundaries.
+		if unicode.IsUpper(r) && len(current) > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			result = append(result, string(current))
+			current = []rune{}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Template functions that don't depend on the seed.
+var helperFuncMap = template.FuncMap{
+	"add": arithmetic(func(a int, b int) (int, error) { return a + b, nil }),
+	"sub": arithmetic(func(a int, b int) (int, error) { return a - b, nil }),
+	"mul": arithmetic(func(a int, b int) (int, error) { return a * b, nil }),
+	"div": arithmetic(func(a int, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return a / b, nil
+	}),
+	"mod": arithmetic(func(a int, b int) (int, error) {
+		if b == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return a % b, nil
+	}),
+	// Numbers from start to end, inclusive.
+	"seq": func(start any, end any) ([]int, error) {
+		startInt, err := toInt(start)
+		if err != nil {
+			return nil, err
+		}
+		endInt, err := toInt(end)
+		if err != nil {
+			return nil, err
+		}
+		ret := []int{}
+		for i := startInt; i <= endInt; i++ {
+			ret = append(ret, i)
+		}
+		return ret, nil
+	},
+	"dict": func(pairs ...any) (map[string]any, error) {
+		if len(pairs)%2 != 0 {
+			return nil, fmt.Errorf("dict requires pairs of keys and values")
+		}
+		ret := map[string]any{}
+		for i := 0; i < len(pairs); i += 2 {
+			ret[fmt.Sprint(pairs[i])] = pairs[i+1]
+		}
+		return ret, nil
+	},
+	"list": func(items ...any) []any {
+		return items
+	},
+	// Returns the value, or the default if the value is empty, ex: {{ default "free" (index . "plan") }}
+	"default": func(defaultValue any, value any) any {
+		if value == nil {
+			return defaultValue
+		}
+		reflected := reflect.ValueOf(value)
+		if reflected.IsZero() || (reflected.Kind() == reflect.Slice || reflected.Kind() == reflect.Map) && reflected.Len() == 0 {
+			return defaultValue
+		}
+		return value
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"title": func(value string) string {
+		result := words(value)
+		for i, word := range result {
+			result[i] = capitalize(word)
+		}
+		return strings.Join(result, " ")
+	},
+	"camel": func(value string) string {
+		result := words(value)
+		for i, word := range result {
+			if i > 0 {
+				result[i] = capitalize(word)
+			}
+		}
+		return strings.Join(result, "")
+	},
+	"snake": func(value string) string {
+		return strings.Join(words(value), "_")
+	},
+	"kebab": func(value string) string {
+		return strings.Join(words(value), "-")
+	},
+	// Like kebab, but only keeps ASCII letters and numbers, for use in URLs.
+	"slug": func(value string) string {
+		result := []string{}
+		for _, word := range words(value) {
+			word = identifierPart(word)
+			if word != "" {
+				result = append(result, word)
+			}
+		}
+		return strings.Join(result, "-")
+	},
+	// Arguments are ordered for pipelines, ex: {{ .tags | join ", " }}
+	"join": func(separator string, value any) (string, error) {
+		list, err := toList(value)
+		if err != nil {
+			return "", err
+		}
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, separator), nil
+	},
+	"split": func(separator string, value string) []string {
+		return strings.Split(value, separator)
+	},
+}
+
+// Template functions that are seeded like valueFuncs, so that the same seed always gives the same result.
+func seededFuncMap(globalSeed string) template.FuncMap {
+	newRand := func(seed any) *randv2.Rand {
+		return randv2.New(randv2.NewChaCha8(seedHash(globalSeed, fmt.Sprint(seed))))
+	}
+	return template.FuncMap{
+		// Picks n random items from a list without replacement, ex: {{ sample .tags 2 .rowId }}
+		"sample": func(value any, n any, seed any) ([]any, error) {
+			list, err := toList(value)
+			if err != nil {
+				return nil, err
+			}
+			count, err := toInt(n)
+			if err != nil {
+				return nil, err
+			}
+			if count < 0 || count > len(list) {
+				return nil, fmt.Errorf("cannot sample %d items from a list of %d", count, len(list))
+			}
+			r := newRand(seed)
+			r.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+			return list[:count], nil
+		},
+		// Returns the list in a random order, ex: {{ range shuffle .users .rowId }}
+		"shuffle": func(value any, seed any) ([]any, error) {
+			list, err := toList(value)
+			if err != nil {
+				return nil, err
+			}
+			r := newRand(seed)
+			r.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+			return list, nil
+		},
+	}
+}
+
+// valueFuncs that depend on other rows, which don't exist yet when templates are rendered.
+var rowValueFuncs = []string{"pick", "after", "before", "seq"}
+
+// Lets templates call valueFuncs at render time, ex: {{ $plan := fake "oneOf" .rowId "free" "pro" }}
+type templateValueFuncs struct {
+	vc      *valueFuncContext
+	ctx     context.Context
+	plugins map[string]RipoffPlugin
+	// Called before plugins are started, so that users can confirm they are safe to run.
+	confirmPlugins func(plugins map[string]RipoffPlugin) error
+	pluginsStarted bool
+	// The row that is rendering a template, for row level options like ~locale.
+	row Row
+	// The directory of the template being rendered, which file() paths are relative to.
+	dir string
+}
+
+func newTemplateValueFuncs(ctx context.Context, totalRipoff RipoffFile, enums EnumValuesResult, options RipoffOptions) *templateValueFuncs {
+	// Plugins are only started if a template calls one of their valueFuncs.
+	emptyManager := &PluginManager{valueFuncMap: map[string]RipoffPlugin{}}
+	return &templateValueFuncs{
+		vc:             newValueFuncContext(emptyManager, totalRipoff, enums),
+		ctx:            ctx,
+		plugins:        totalRipoff.Plugins,
+		confirmPlugins: options.ConfirmPlugins,
+	}
+}
+
+func (t *templateValueFuncs) isPluginValueFunc(methodName string) bool {
+	for _, plugin := range t.plugins {
+		if slices.Contains(plugin.ValueFuncs, methodName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *templateValueFuncs) startPlugins() error {
+	if t.confirmPlugins != nil {
+		err := t.confirmPlugins(t.plugins)
+		if err != nil {
+			return err
+		}
+	}
+	manager, err := NewPluginManager(t.ctx, t.plugins)
+	if err != nil {
+		return err
+	}
+	t.vc.manager = manager
+	t.pluginsStarted = true
+	return nil
+}
+
+// Returns the same value as the equivalent valueFunc in a row. Field paths are part of the name, ex: fake "person.email" .rowId
+func (t *templateValueFuncs) fake(name string, args ...any) (string, error) {
+	methodName, fieldPath, hasFieldPath := strings.Cut(name, ".")
+	if slices.Contains(rowValueFuncs, methodName) {
+		return "", fmt.Errorf("fake cannot call %s, since rows do not exist until templates are rendered", methodName)
+	}
+	if len(args) == 0 || fmt.Sprint(args[0]) == "" {
+		return "", fmt.Errorf("fake requires a seed, ex: fake \"%s\" .rowId", name)
+	}
+	if !t.pluginsStarted && t.isPluginValueFunc(methodName) {
+		err := t.startPlugins()
+		if err != nil {
+			return "", err
+		}
+	}
+	if hasFieldPath && !slices.Contains(structuredValueFuncs, methodName) {
+		return "", fmt.Errorf("fake %s: only %s have fields", name, strings.Join(structuredValueFuncs, ", "))
+	}
+	// Arguments are passed as-is instead of being parsed from a string, so they can contain commas.
+	valueParts := make([]string, len(args))
+	for i, arg := range args {
+		valueParts[i] = fmt.Sprint(arg)
+	}
+	vc := t.vc.forRow(t.row)
+	if t.dir != "" {
+		vc.dir = t.dir
+	}
+	value, _, err := prepareValueParts(vc, "", "", methodName, strings.Join(valueParts, ", "), valueParts, fieldPath)
+	if err != nil {
+		return "", fmt.Errorf("fake %s: %w", name, err)
+	}
+	return value, nil
+}
+
+func (t *templateValueFuncs) close() {
+	t.vc.manager.Close()
+}
@@ -0,0 +1,9 @@
+CREATE TABLE attachments (
+  id UUID NOT NULL PRIMARY KEY,
+  avatar BYTEA NOT NULL,
+  banner BYTEA NOT NULL,
+  nonce BYTEA NOT NULL,
+  contents BYTEA NOT NULL,
+  avatar_base64 TEXT NOT NULL,
+  nonce_hex TEXT NOT NULL
+);
@@ -0,0 +1,14 @@
+WITH test AS (
+  SELECT count(*) as count FROM attachments
+  WHERE id = '7b6a0895-b986-431c-b2f0-9360406a60b2'
+  -- PNG signature.
+  AND substring(avatar from 1 for 8) = '\x89504e470d0a1a0a'::bytea
+  AND md5(avatar) = 'ecff5c9f03889b9d9837c82f0a7f409c'
+  AND md5(banner) = '3d105cbff8c3944782b8d7425e978d1b'
+  AND length(nonce) = 16 AND nonce = '\x2162cab1408cc29f148213134989819b'::bytea
+  AND contents = convert_to(E'hello, ripoff\n', 'UTF8')
+  AND avatar_base64 = replace(encode(avatar, 'base64'), E'\n', '')
+  AND nonce_hex = encode(nonce, 'hex')
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || md5(avatar) || ' ' || md5(banner) || ' ' || encode(nonce, 'hex') || ' ' || encode(contents, 'escape'), ', ')
+FROM attachments;
@@ -0,0 +1,9 @@
+CREATE TABLE accounts (
+  id UUID NOT NULL PRIMARY KEY,
+  status TEXT NOT NULL,
+  plan TEXT NOT NULL,
+  verified BOOLEAN NOT NULL,
+  always_verified BOOLEAN NOT NULL,
+  balance NUMERIC(10, 2) NOT NULL,
+  score DOUBLE PRECISION NOT NULL
+);
@@ -0,0 +1,12 @@
+WITH test AS (
+  SELECT count(*) as count FROM accounts
+  WHERE status IN ('draft', 'published', 'archived')
+  AND plan IN ('free', 'pro', 'enterprise')
+  AND always_verified
+  AND (
+    (id = 'e89a8519-970b-48ab-b0fd-df0225270771' AND status = 'published' AND plan = 'free' AND balance = 57.42 AND score >= 0 AND score < 1)
+    OR (id = '95c6ba23-de86-4363-b25f-76efd49a2ee0' AND plan = 'pro' AND balance = 16 AND score >= 0 AND score < 100)
+  )
+)
+SELECT (select count from test) = 2, string_agg(id || ' ' || status || ' ' || plan || ' ' || verified || ' ' || balance || ' ' || score, ', ')
+FROM accounts;
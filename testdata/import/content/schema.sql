@@ -0,0 +1,6 @@
+CREATE TABLE articles (
+  id UUID NOT NULL PRIMARY KEY,
+  body_markdown TEXT NOT NULL,
+  body_html TEXT NOT NULL,
+  summary TEXT NOT NULL
+);
@@ -0,0 +1,12 @@
+WITH test AS (
+  SELECT count(*) as count FROM articles
+  WHERE id = 'a17c5f51-fcc0-4e12-b187-b828531e0753'
+  AND md5(body_markdown) = '4faf2510cb152dbd00ff360132a3cf28'
+  AND md5(body_html) = '5a2b51e70d7e08fd1b42a28ee3751ead'
+  AND md5(summary) = '4d1557f909b90c4564d25d53e5ff75e0'
+  AND body_markdown LIKE '# Quo minima tempore tenetur eos%'
+  AND body_html LIKE '<h1>Quo minima tempore tenetur eos</h1>%'
+  AND summary NOT LIKE '%- %' AND summary NOT LIKE '%```%'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || body_markdown || ' ' || body_html || ' ' || summary, ', ')
+FROM articles;
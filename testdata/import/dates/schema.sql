@@ -0,0 +1,8 @@
+CREATE TABLE events (
+  id UUID NOT NULL PRIMARY KEY,
+  happened_at TIMESTAMPTZ NOT NULL,
+  happened_on DATE NOT NULL,
+  recent_on DATE NOT NULL,
+  starts_at TIME NOT NULL,
+  local_label TEXT NOT NULL
+);
@@ -0,0 +1,13 @@
+WITH test AS (
+  SELECT count(*) as count FROM events
+  WHERE happened_at >= '2023-01-01' AND happened_at < '2024-01-01'
+  AND happened_on >= '2023-01-01' AND happened_on < '2023-02-01'
+  AND recent_on >= current_date - 90 AND recent_on < current_date
+  AND starts_at >= '09:00' AND starts_at < '17:00'
+  AND (
+    (id = 'a17c5f51-fcc0-4e12-b187-b828531e0753' AND happened_at = '2023-07-04T16:08:58Z' AND local_label = '2023-06-01 21:27 CEST')
+    OR (id = 'f5d971bb-b53d-41bf-8e1f-fc9bd8525937' AND happened_at = '2023-01-01T12:36:10Z' AND happened_on = '2023-01-01' AND starts_at = '09:15:32' AND local_label = '2023-06-01')
+  )
+)
+SELECT (select count from test) = 2, string_agg(id || ' ' || happened_at || ' ' || happened_on || ' ' || recent_on || ' ' || starts_at || ' ' || local_label, ', ')
+FROM events;
@@ -0,0 +1,10 @@
+CREATE TABLE plans (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL
+);
+CREATE TABLE prescriptions (
+  id UUID NOT NULL PRIMARY KEY,
+  drug TEXT NOT NULL,
+  sku TEXT NOT NULL,
+  plan TEXT NOT NULL
+);
@@ -0,0 +1,10 @@
+WITH test AS (
+  SELECT
+    (SELECT count(*) FROM plans WHERE name IN ('free', 'pro', 'enterprise')) as plans,
+    (SELECT count(*) FROM prescriptions WHERE
+      (id = '7b6a0895-b986-431c-b2f0-9360406a60b2' AND drug = 'Lisinopril' AND sku = 'RX-100' AND plan = 'free')
+      OR (id = 'f48aa13c-f07b-4b5d-8417-a7990efc3578' AND drug = 'Lisinopril' AND sku = 'RX-100' AND plan = 'free')
+    ) as prescriptions
+)
+SELECT (select plans from test) = 3 AND (select prescriptions from test) = 2, string_agg(id || ' ' || drug || ' ' || sku || ' ' || plan, ', ')
+FROM prescriptions;
@@ -0,0 +1,7 @@
+CREATE TABLE orders (
+  id UUID NOT NULL PRIMARY KEY,
+  total NUMERIC(10, 2) NOT NULL,
+  page_views INTEGER NOT NULL,
+  session_seconds INTEGER NOT NULL,
+  rating DOUBLE PRECISION NOT NULL
+);
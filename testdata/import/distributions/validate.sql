@@ -0,0 +1,14 @@
+WITH test AS (
+  SELECT count(*) as count FROM orders
+  WHERE total > 0
+  AND page_views >= 0 AND page_views <= 1000
+  AND session_seconds >= 0
+  AND rating >= 1 AND rating <= 5 AND rating = round(rating::numeric, 1)
+)
+SELECT (select count from test) = 50
+  -- The lognormal median is e^3.5, or about 33.
+  AND (SELECT percentile_cont(0.5) WITHIN GROUP (ORDER BY total) FROM orders) BETWEEN 15 AND 70
+  -- Zipf values are heavily skewed towards zero.
+  AND (SELECT count(*) FROM orders WHERE page_views < 10) > 25,
+  string_agg(total || ' ' || page_views || ' ' || session_seconds || ' ' || rating, ', ')
+FROM orders;
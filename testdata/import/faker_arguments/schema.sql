@@ -0,0 +1,10 @@
+CREATE TABLE products (
+  id UUID NOT NULL PRIMARY KEY,
+  price NUMERIC(10, 2) NOT NULL,
+  stock INTEGER NOT NULL,
+  secret TEXT NOT NULL,
+  released_at TIMESTAMPTZ NOT NULL,
+  color INTEGER[] NOT NULL,
+  discontinued BOOLEAN NOT NULL,
+  http_method TEXT NOT NULL
+);
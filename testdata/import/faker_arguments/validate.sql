@@ -0,0 +1,12 @@
+WITH test AS (
+  SELECT count(*) as count FROM products
+  WHERE id = 'd1e01f53-b9ec-483b-ad8d-2d2c0a1d6a5e'
+  AND price = 81.69
+  AND stock = 362
+  AND secret = 'rYvpk5B0FY8d'
+  AND released_at = '2020-09-04T03:42:48Z'
+  AND color = '{209,59,106}'
+  AND http_method = 'HEAD'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || price || ' ' || stock || ' ' || secret || ' ' || released_at || ' ' || color::text || ' ' || http_method, ', ')
+FROM products;
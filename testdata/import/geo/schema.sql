@@ -0,0 +1,9 @@
+-- PostGIS isn't required for tests, but geometry columns accept the same EWKT text.
+CREATE TABLE stores (
+  id UUID NOT NULL PRIMARY KEY,
+  lat NUMERIC(9, 6) NOT NULL,
+  lng NUMERIC(9, 6) NOT NULL,
+  location TEXT NOT NULL,
+  delivery_area TEXT NOT NULL,
+  geohash TEXT NOT NULL
+);
@@ -0,0 +1,11 @@
+WITH test AS (
+  SELECT count(*) as count FROM stores
+  WHERE id = 'e5a92ac5-551f-41be-9b04-8469d2e0e716'
+  AND lat = 52.533727 AND lng = 13.165196
+  -- The same seed generates the same point, in longitude latitude order.
+  AND location = 'SRID=4326;POINT(13.165196 52.533727)'
+  AND delivery_area = 'SRID=4326;POLYGON((13.410374 52.52008,13.403798 52.52345,13.400152 52.519327,13.402743 52.518035,13.408196 52.51656,13.410374 52.52008))'
+  AND geohash = 'u33ds0k'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || lat || ' ' || lng || ' ' || location || ' ' || delivery_area || ' ' || geohash, ', ')
+FROM stores;
@@ -0,0 +1,8 @@
+CREATE TABLE accounts (
+  id TEXT NOT NULL PRIMARY KEY,
+  ksuid TEXT NOT NULL,
+  slug TEXT NOT NULL,
+  short_slug TEXT NOT NULL,
+  snowflake BIGINT NOT NULL,
+  namespaced_id UUID NOT NULL
+);
@@ -0,0 +1,13 @@
+WITH test AS (
+  SELECT count(*) as count FROM accounts
+  WHERE id = '000CGGM3ATSM1S32M9SYJYN4JY'
+  AND ksuid = '03yuY8pu7LgksRtUmccfoBkjybf'
+  AND slug = 'Q7SkqkvyuSPc-jn79o_CG'
+  AND short_slug = '4f6b5063'
+  AND snowflake = 169659260320403127
+  -- The machine ID is stored in bits 12-21.
+  AND (snowflake >> 12) & 1023 = 12
+  AND namespaced_id = 'ce0a3e45-99ec-5bf2-8de2-e04afe375b05'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || ksuid || ' ' || slug || ' ' || short_slug || ' ' || snowflake || ' ' || namespaced_id, ', ')
+FROM accounts;
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+  id UUID NOT NULL PRIMARY KEY,
+  email TEXT NOT NULL,
+  backup_email TEXT NOT NULL
+);
+
+CREATE TABLE posts (
+  id UUID NOT NULL PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users,
+  title TEXT NOT NULL
+);
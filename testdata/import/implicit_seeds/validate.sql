@@ -0,0 +1,12 @@
+WITH test AS (
+  SELECT count(*) as count FROM users
+  WHERE (id = '1ebdb5ae-830f-46a3-a887-6a30f9ccd868' AND email = 'mckaylakirlin@wilkinson.org' AND backup_email = 'nyahmetz@bauch.name')
+  OR (id = '33d4e523-a550-4cf0-878c-79243bfcab05' AND email = 'danekemmer@cormier.net' AND backup_email = 'genovevagibson@corkery.com')
+  OR (id = '7daf0c20-e071-4e30-a130-1d16b07545fd' AND email = 'tristonfisher@greenfelder.com' AND backup_email = email)
+), posts_test AS (
+  SELECT count(*) as count FROM posts
+  JOIN users ON users.id = posts.user_id
+  WHERE users.email != users.backup_email
+)
+SELECT (select count from test) = 3 AND (select count from posts_test) = 1, string_agg(id || ' ' || email || ' ' || backup_email, ', ')
+FROM users;
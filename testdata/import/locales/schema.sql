@@ -0,0 +1,9 @@
+CREATE TABLE customers (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL,
+  phone TEXT NOT NULL,
+  zip TEXT NOT NULL,
+  city TEXT NOT NULL,
+  country TEXT NOT NULL
+);
@@ -0,0 +1,8 @@
+WITH test AS (
+  SELECT count(*) as count FROM customers
+  WHERE (id = 'e5a92ac5-551f-41be-9b04-8469d2e0e716' AND name = 'Michael Schulz' AND email = 'michael.schulz@legacy24-7.de' AND phone = '0461 7761550' AND city = 'Kiel' AND country = 'Deutschland')
+  OR (id = '77d31962-2fb2-4cab-8f7d-16bf44988c45' AND name = '清水 蓮' AND email = 'ren.shimizu@forwardbandwidth.jp' AND zip = '397-5919' AND country = '日本')
+  OR (id = 'd764ad2f-c5b9-446c-814a-834dcd91f655' AND name = 'Toney Marvin' AND city = 'Irving')
+)
+SELECT (select count from test) = 3, string_agg(id || ' ' || name || ' ' || email || ' ' || phone || ' ' || zip || ' ' || city || ' ' || country, ', ')
+FROM customers;
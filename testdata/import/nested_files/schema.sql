@@ -0,0 +1,5 @@
+CREATE TABLE documents (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  contents BYTEA NOT NULL
+);
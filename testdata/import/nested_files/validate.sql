@@ -0,0 +1,8 @@
+WITH test AS (
+  SELECT count(*) as count FROM documents
+  WHERE (name = 'user note' AND contents = convert_to(E'from users\n', 'UTF8'))
+  OR (name = 'template note' AND contents = convert_to(E'from templates\n', 'UTF8'))
+  OR (name = 'template email' AND contents = convert_to(E'Hi {{ name\n', 'UTF8'))
+)
+SELECT (select count from test) = 3, string_agg(name || ' ' || encode(contents, 'escape'), ', ')
+FROM documents;
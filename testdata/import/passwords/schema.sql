@@ -0,0 +1,9 @@
+CREATE TABLE users (
+  id UUID NOT NULL PRIMARY KEY,
+  email TEXT NOT NULL,
+  password_hash TEXT NOT NULL,
+  legacy_password_hash TEXT NOT NULL,
+  api_token TEXT NOT NULL,
+  api_token_sha256 TEXT NOT NULL,
+  webhook_signature TEXT NOT NULL
+);
@@ -0,0 +1,11 @@
+WITH test AS (
+  SELECT count(*) as count FROM users
+  WHERE id = '2151d809-9446-4a06-b88c-a8ca1a6e4f6d'
+  AND password_hash = '$2a$04$UjbrHw7Cuy34UijY2tXpPeZTiVGCdEsNZWklwXP7dMV0h.1hVWEXW'
+  AND legacy_password_hash = '$argon2id$v=19$m=1024,t=1,p=1$hW8lwweJk0pzv6MUr7wpRg$+E1QLaR7mMTiBbupuBAhfqJ3jqhbSr5X7TfJSf3hcS0'
+  AND api_token = 'sk_test_KpxivpthnwYxyzqZvMuaigKrtUclplq7'
+  AND api_token_sha256 = encode(sha256(api_token::bytea), 'hex')
+  AND webhook_signature = 'cfb82bcabc080b39a282ea8683315914dcb2501a46b71627042a5bd6aa2162ef'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || password_hash || ' ' || legacy_password_hash || ' ' || api_token || ' ' || api_token_sha256 || ' ' || webhook_signature, ', ')
+FROM users;
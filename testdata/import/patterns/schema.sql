@@ -0,0 +1,6 @@
+CREATE TABLE products (
+  id UUID NOT NULL PRIMARY KEY,
+  sku TEXT NOT NULL,
+  invoice_number TEXT NOT NULL,
+  license_plate TEXT NOT NULL
+);
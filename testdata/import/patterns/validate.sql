@@ -0,0 +1,9 @@
+WITH test AS (
+  SELECT count(*) as count FROM products
+  WHERE id = 'd1e01f53-b9ec-483b-ad8d-2d2c0a1d6a5e'
+  AND sku = 'JFV-7612' AND sku ~ '^[A-Z]{3}-\d{4}$'
+  AND invoice_number = 'INV-0120-PE'
+  AND license_plate = 'P-XL 78'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || sku || ' ' || invoice_number || ' ' || license_plate, ', ')
+FROM products;
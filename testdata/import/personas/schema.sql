@@ -0,0 +1,20 @@
+CREATE TABLE users (
+  id UUID NOT NULL PRIMARY KEY,
+  first_name TEXT NOT NULL,
+  last_name TEXT NOT NULL,
+  email TEXT NOT NULL,
+  city TEXT NOT NULL
+);
+
+CREATE TABLE profiles (
+  id UUID NOT NULL PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users,
+  display_name TEXT NOT NULL,
+  details JSONB NOT NULL
+);
+
+CREATE TABLE offices (
+  id UUID NOT NULL PRIMARY KEY,
+  street TEXT NOT NULL,
+  zip TEXT NOT NULL
+);
@@ -0,0 +1,11 @@
+WITH test AS (
+  SELECT count(*) as count FROM users
+  JOIN profiles ON profiles.user_id = users.id
+  WHERE users.email LIKE lower(users.first_name) || '.' || lower(users.last_name) || '@%'
+  AND profiles.display_name = users.first_name || ' ' || users.last_name
+  AND profiles.details->>'first_name' = users.first_name
+  AND profiles.details->'address'->>'city' = users.city
+  AND users.email = 'ena.barton@productleverage.net'
+)
+SELECT (select count from test) = 1, string_agg(users.first_name || ' ' || users.last_name || ' ' || users.email || ' ' || users.city, ', ')
+FROM users;
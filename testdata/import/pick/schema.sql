@@ -0,0 +1,11 @@
+CREATE TYPE user_role AS ENUM ('admin', 'power', 'normie', 'banned');
+
+CREATE TABLE users (
+  id UUID NOT NULL PRIMARY KEY,
+  role user_role NOT NULL
+);
+
+CREATE TABLE tasks (
+  id UUID NOT NULL PRIMARY KEY,
+  assignee_id UUID NOT NULL REFERENCES users
+);
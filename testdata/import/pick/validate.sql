@@ -0,0 +1,11 @@
+WITH test AS (
+  SELECT count(*) as count FROM tasks
+  JOIN users ON users.id = tasks.assignee_id
+)
+-- Foreign keys ensure that picked users exist, but we also want to make sure
+-- that picks are deterministic.
+SELECT (select count from test) = 3
+  AND (SELECT role FROM users WHERE id = '33d4e523-a550-4cf0-878c-79243bfcab05') = 'normie'
+  AND (SELECT assignee_id FROM tasks WHERE id = '54d97cae-c21e-4d87-a5e8-10b2f476fbaf') = '1ebdb5ae-830f-46a3-a887-6a30f9ccd868',
+  string_agg(id || ' ' || assignee_id, ', ')
+FROM tasks;
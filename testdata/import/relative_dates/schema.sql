@@ -0,0 +1,10 @@
+CREATE TABLE posts (
+  id UUID NOT NULL PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE comments (
+  id UUID NOT NULL PRIMARY KEY,
+  post_id UUID NOT NULL REFERENCES posts,
+  created_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,12 @@
+WITH test AS (
+  SELECT count(*) as count FROM comments
+  JOIN posts ON posts.id = comments.post_id
+  WHERE comments.created_at >= posts.created_at + interval '1 hour'
+  AND comments.created_at < posts.created_at + interval '72 hours'
+)
+SELECT (select count from test) = 3
+  AND (SELECT count(distinct created_at) FROM comments) = 3
+  AND (SELECT created_at FROM comments WHERE id = 'bedfc9f7-bb07-4f28-91b0-ba68aab7a188') = (SELECT created_at + interval '30 minutes' FROM comments WHERE id = '1aac6d70-dd3a-491b-b254-18f93556ae25')
+  AND (SELECT created_at FROM posts WHERE id = 'a92a6bfd-48b5-490e-bbc2-b4b7940a7c84') = '2023-02-11T06:42:30Z',
+  string_agg(id || ' ' || created_at, ', ')
+FROM comments;
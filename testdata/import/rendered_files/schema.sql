@@ -0,0 +1,13 @@
+CREATE TYPE plan_tier AS ENUM ('free', 'pro');
+
+CREATE TABLE plans (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  tier plan_tier NOT NULL
+);
+
+CREATE TABLE accounts (
+  id UUID NOT NULL PRIMARY KEY,
+  plan_id UUID NOT NULL REFERENCES plans,
+  name TEXT NOT NULL
+);
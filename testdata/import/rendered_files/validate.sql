@@ -0,0 +1,9 @@
+WITH plan_accounts AS (
+  SELECT plans.id, count(accounts.id) as count FROM plans
+  JOIN accounts ON accounts.plan_id = plans.id
+  GROUP BY plans.id
+)
+SELECT (SELECT count(*) FROM plans WHERE name IN ('free (us)', 'free (eu)', 'pro (us)', 'pro (eu)') AND name LIKE tier || ' (%') = 4
+  AND (SELECT count(*) FROM plan_accounts WHERE count = 2) = 4
+  AND (SELECT count(*) FROM accounts WHERE name IN ('Missouri', 'Marilyne', 'Wanda', 'Hilma', 'Jewell', 'Nathan', 'Amelia', 'Craig')) = 8,
+  (SELECT string_agg(name, ', ') FROM accounts);
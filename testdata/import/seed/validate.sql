@@ -0,0 +1,7 @@
+-- Without a seed, uuid(fooBar) is 6b30cfb0-a35b-4584-a035-1334515f846b.
+WITH test AS (
+  SELECT count(*) as count FROM users
+  WHERE id = '86cedb9f-57d6-403e-8607-9ee68f8cc6d4' AND email = 'vidawolf@jast.name'
+)
+SELECT (select count from test) = 1, string_agg(id || ' ' || email, ', ')
+FROM users;
@@ -0,0 +1,9 @@
+CREATE TABLE tickets (
+  id UUID NOT NULL PRIMARY KEY,
+  number INTEGER NOT NULL,
+  position INTEGER NOT NULL
+);
+CREATE TABLE invoices (
+  id UUID NOT NULL PRIMARY KEY,
+  number INTEGER NOT NULL
+);
@@ -0,0 +1,15 @@
+WITH test AS (
+  SELECT
+    (SELECT count(*) FROM tickets WHERE number = position + 99) as tickets,
+    (SELECT count(*) FROM tickets WHERE
+      (id = '6da9fde5-685b-42e2-bd6f-556ba39f0670' AND number = 100)
+      OR (id = '21aa4986-1e46-479a-8927-df0126cbc19f' AND number = 109)
+      OR (id = '6ce0c4e3-5db2-44a3-812e-0b5491dd7aa1' AND number = 110)
+    ) as ordered,
+    (SELECT count(*) FROM invoices WHERE
+      (id = '7b6a0895-b986-431c-b2f0-9360406a60b2' AND number = 1000)
+      OR (id = 'f48aa13c-f07b-4b5d-8417-a7990efc3578' AND number = 1010)
+    ) as invoices
+)
+SELECT (select tickets from test) = 12 AND (select ordered from test) = 3 AND (select invoices from test) = 2, string_agg(id || ' ' || number || ' ' || position, ', ')
+FROM tickets;
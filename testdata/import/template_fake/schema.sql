@@ -0,0 +1,16 @@
+CREATE TABLE orgs (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  expected_name TEXT NOT NULL,
+  plan TEXT NOT NULL,
+  expected_plan TEXT NOT NULL,
+  code TEXT NOT NULL,
+  expected_code TEXT NOT NULL,
+  legal_name TEXT NOT NULL
+);
+
+CREATE TABLE seats (
+  id UUID NOT NULL PRIMARY KEY,
+  org_id UUID NOT NULL REFERENCES orgs,
+  email TEXT NOT NULL
+);
@@ -0,0 +1,13 @@
+WITH test AS (
+  SELECT count(*) as count FROM seats
+  WHERE org_id = 'da590808-7609-47cd-8391-8a89cfa5ea92'
+  AND email IN ('mackenzie.beahan@corporatedrive.info', 'cayla.runte@corporatevirtual.org', 'hardy.cole@principalubiquitous.name')
+)
+SELECT (select count from test) = 3
+  AND (SELECT count(*) FROM seats) = 3
+  AND (SELECT count(*) FROM orgs WHERE name = expected_name AND plan = expected_plan AND code = expected_code) = 4
+  AND (SELECT count(*) FROM orgs WHERE legal_name IN ('Acme, Inc.', 'Globex, LLC')) = 4
+  AND (SELECT name FROM orgs WHERE id = '19cfcb5b-8b6c-47d4-8a14-14a00d48b046') = '大和'
+  AND (SELECT plan FROM orgs WHERE id = 'da590808-7609-47cd-8391-8a89cfa5ea92') = 'enterprise',
+  string_agg(name || ' ' || plan || ' ' || expected_plan || ' ' || code || ' ' || legal_name, ', ')
+FROM orgs;
@@ -0,0 +1,10 @@
+CREATE TABLE teams (
+  id UUID NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL,
+  slug TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  parity TEXT NOT NULL,
+  tags TEXT NOT NULL,
+  plan TEXT NOT NULL,
+  lead TEXT NOT NULL
+);
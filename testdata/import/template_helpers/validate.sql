@@ -0,0 +1,8 @@
+WITH test AS (
+  SELECT count(*) as count FROM teams
+  WHERE (id = '5bdde588-10b2-46ac-bb7d-2ce4c08bfbaa' AND name = 'Platform Team 001' AND slug = 'platform-team-1' AND position = 10 AND parity = 'odd' AND tags = 'infra,backend' AND plan = 'free' AND lead = 'grace_hopper')
+  OR (id = '5d851778-8728-409d-8ed3-19222bb7f62b' AND name = 'Platform Team 002' AND slug = 'platform-team-2' AND position = 20 AND parity = 'even' AND tags = 'data,backend' AND plan = 'free' AND lead = 'ada_lovelace')
+  OR (id = 'cd2c856b-b5bb-4dff-a9d6-3d46741d8b7a' AND name = 'Platform Team 003' AND slug = 'platform-team-3' AND position = 30 AND parity = 'odd' AND tags = 'infra,frontend' AND plan = 'free' AND lead = 'alan_turing')
+)
+SELECT (select count from test) = 3, string_agg(id || ' ' || name || ' ' || slug || ' ' || position || ' ' || parity || ' ' || tags || ' ' || plan || ' ' || lead, ', ')
+FROM teams;
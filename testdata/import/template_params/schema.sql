@@ -0,0 +1,7 @@
+CREATE TABLE members (
+  id UUID NOT NULL PRIMARY KEY,
+  email TEXT NOT NULL,
+  role TEXT NOT NULL,
+  nickname TEXT,
+  seats INTEGER NOT NULL
+);
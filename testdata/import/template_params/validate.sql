@@ -0,0 +1,7 @@
+WITH test AS (
+  SELECT count(*) as count FROM members
+  WHERE (id = '1ebdb5ae-830f-46a3-a887-6a30f9ccd868' AND email = 'alice@example.com' AND role = 'admin' AND seats = 5 AND nickname = 'ali')
+  OR (id = '33d4e523-a550-4cf0-878c-79243bfcab05' AND email = 'bob@example.com' AND role = 'member' AND seats = 2 AND nickname IS NULL)
+)
+SELECT (select count from test) = 2, string_agg(email || ' ' || role || ' ' || seats || ' ' || coalesce(nickname, 'null'), ', ')
+FROM members;
@@ -0,0 +1,16 @@
+CREATE TABLE customers (
+  id UUID NOT NULL PRIMARY KEY,
+  email TEXT NOT NULL
+);
+
+CREATE TABLE invoices (
+  id UUID NOT NULL PRIMARY KEY,
+  customer_id UUID NOT NULL REFERENCES customers,
+  description TEXT NOT NULL
+);
+
+CREATE TABLE shipments (
+  id UUID NOT NULL PRIMARY KEY,
+  customer_id UUID NOT NULL REFERENCES customers,
+  description TEXT NOT NULL
+);
@@ -0,0 +1,4 @@
+SELECT (SELECT count(*) FROM customers) = 2
+  AND (SELECT count(*) FROM invoices WHERE description = 'invoice' AND customer_id IN ('1ebdb5ae-830f-46a3-a887-6a30f9ccd868', '33d4e523-a550-4cf0-878c-79243bfcab05')) = 2
+  AND (SELECT count(*) FROM shipments WHERE description = 'shipment' AND customer_id IN ('1ebdb5ae-830f-46a3-a887-6a30f9ccd868', '33d4e523-a550-4cf0-878c-79243bfcab05')) = 2,
+  (SELECT string_agg(id || ' ' || email, ', ') FROM customers);
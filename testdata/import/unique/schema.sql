@@ -0,0 +1,5 @@
+CREATE TABLE users (
+  id UUID NOT NULL PRIMARY KEY,
+  username TEXT NOT NULL UNIQUE,
+  nickname TEXT NOT NULL
+);
@@ -0,0 +1,5 @@
+WITH test AS (
+  SELECT count(DISTINCT username) as usernames, count(DISTINCT nickname) as nicknames FROM users
+)
+SELECT (select usernames from test) = 4 AND (select nicknames from test) = 1, string_agg(id || ' ' || username || ' ' || nickname, ', ')
+FROM users;
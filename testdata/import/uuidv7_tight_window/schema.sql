@@ -0,0 +1,4 @@
+CREATE TABLE events (
+  id UUID NOT NULL PRIMARY KEY,
+  position INTEGER NOT NULL
+);
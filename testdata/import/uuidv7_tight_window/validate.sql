@@ -0,0 +1,14 @@
+WITH ordered AS (
+  SELECT
+    position,
+    row_number() OVER (ORDER BY id) as id_order,
+    -- The first 48 bits of a v7 UUID are a unix timestamp in milliseconds.
+    ('x' || substr(replace(id::text, '-', ''), 1, 12))::bit(48)::bigint as created_at_ms
+  FROM events
+)
+SELECT
+  count(*) = 1000
+    AND bool_and(position = id_order)
+    AND bool_and(created_at_ms = extract(epoch from timestamptz '2024-01-01T00:00:00Z')::bigint * 1000),
+  coalesce(string_agg(position || ' ' || id_order, ', ') FILTER (WHERE position != id_order), 'all in order')
+FROM ordered;
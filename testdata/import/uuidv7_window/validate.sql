@@ -0,0 +1,14 @@
+WITH ordered AS (
+  SELECT
+    position,
+    row_number() OVER (ORDER BY id) as id_order,
+    -- The first 48 bits of a v7 UUID are a unix timestamp in milliseconds.
+    to_timestamp(('x' || substr(replace(id::text, '-', ''), 1, 12))::bit(48)::bigint / 1000.0) as created_at
+  FROM events
+)
+SELECT
+  count(*) = 25
+    AND bool_and(position = id_order)
+    AND bool_and(created_at >= '2024-01-01T00:00:00Z' AND created_at < '2024-06-01T00:00:00Z'),
+  string_agg(position || ' ' || id_order || ' ' || created_at, ', ')
+FROM ordered;
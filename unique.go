@@ -0,0 +1,110 @@
+package ripoff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// The number of times a value is re-generated before giving up on finding a unique value.
+const maxUniqueAttempts = 100
+
+// Only single column unique indexes are tracked, since those are where faker values usually collide.
+const uniqueColumnsQuery = `
+SELECT STRING_AGG(a.attname, '|' order by a.attname), t.relname
+FROM pg_index i
+JOIN pg_class t ON t.oid = i.indrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = i.indkey[0]
+WHERE n.nspname = 'public'
+AND i.indisunique
+AND NOT i.indisprimary
+AND i.indnatts = 1
+AND i.indpred IS NULL
+GROUP BY t.relname;
+`
+
+type UniqueColumnsResult map[string][]string
+
+func getUniqueColumns(ctx context.Context, tx pgx.Tx) (UniqueColumnsResult, error) {
+	rows, err := tx.Query(ctx, uniqueColumnsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allUniqueColumns := UniqueColumnsResult{}
+
+	for rows.Next() {
+		var columns string
+		var tableName string
+		err = rows.Scan(&columns, &tableName)
+		if err != nil {
+			return nil, err
+		}
+		allUniqueColumns[tableName] = slices.Compact(strings.Split(columns, "|"))
+	}
+	return allUniqueColumns, nil
+}
+
+// Finds generated values that collide in unique columns, and salts their seeds until they're unique.
+// Rows are visited in order so that the same ripoff always resolves collisions the same way.
+func (vc *valueFuncContext) resolveUniqueCollisions(uniqueColumns UniqueColumnsResult) error {
+	rowIds := []string{}
+	for rowId := range vc.rows {
+		rowIds = append(rowIds, rowId)
+	}
+	slices.Sort(rowIds)
+
+	type uniqueCandidate struct {
+		rowId  string
+		column string
+		value  string
+	}
+	seen := map[string]map[string]bool{}
+	candidates := []uniqueCandidate{}
+	for _, rowId := range rowIds {
+		table, _, _ := strings.Cut(rowId, ":")
+		for _, column := range uniqueColumns[table] {
+			valueRaw, hasColumn := vc.rows[rowId][column]
+			if !hasColumn || valueRaw == nil {
+				continue
+			}
+			key := table + "." + column
+			if seen[key] == nil {
+				seen[key] = map[string]bool{}
+			}
+			value := fmt.Sprint(valueRaw)
+			// Static values and references to other rows are left as-is, so generated values avoid them instead.
+			matches := valueFuncRegex.FindStringSubmatch(value)
+			if len(matches) != 3 || matches[1] == "literal" || referenceRegex.MatchString(value) {
+				seen[key][value] = true
+				continue
+			}
+			candidates = append(candidates, uniqueCandidate{rowId, column, value})
+		}
+	}
+
+	for _, candidate := range candidates {
+		table, _, _ := strings.Cut(candidate.rowId, ":")
+		key := table + "." + candidate.column
+		saltKey := candidate.rowId + "." + candidate.column
+		unique := false
+		for attempt := 0; attempt < maxUniqueAttempts && !unique; attempt++ {
+			vc.uniqueSalts[saltKey] = attempt
+			value, _, err := prepareValue(vc.forRow(vc.rows[candidate.rowId]), candidate.rowId, candidate.column, candidate.value)
+			if err != nil {
+				return err
+			}
+			unique = !seen[key][value]
+			seen[key][value] = true
+		}
+		if !unique {
+			return fmt.Errorf("could not generate a unique value for %s in row %s after %d attempts, %s may not have enough possible values", key, candidate.rowId, maxUniqueAttempts, candidate.value)
+		}
+	}
+	return nil
+}
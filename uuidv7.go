@@ -8,7 +8,10 @@ package ripoff
 // license that can be found in the LICENSE file.
 
 import (
+	"fmt"
 	"math/rand"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -29,6 +32,86 @@ func NewV7FromReader(r *rand.Rand) (uuid.UUID, error) {
 	return uuid, nil
 }
 
+// The number of 256 nanosecond buckets in a millisecond, which is the sub-millisecond precision of v7 UUIDs.
+// The last bucket is only 64 nanoseconds long, see getV7Time.
+const v7BucketsPerMilli = (nanoPerMilli >> 8) + 1
+
+// Returns the first bucket that starts at or after nano, where buckets are numbered across milliseconds.
+func v7BucketCeil(nano int64) int64 {
+	milli, seq := getV7Time(nano)
+	bucket := milli*v7BucketsPerMilli + seq
+	if v7BucketStart(bucket) < nano {
+		bucket++
+	}
+	return bucket
+}
+
+func v7BucketStart(bucket int64) int64 {
+	return bucket/v7BucketsPerMilli*nanoPerMilli + (bucket%v7BucketsPerMilli)<<8
+}
+
+// newV7InWindow returns a Version 7 UUID with a time in the half-open range [start, end).
+// If count is positive, the range is split into count equal slots and the time is picked from slot index,
+// so that UUIDs with increasing indexes always sort in the same order.
+// Times are picked from whole 256 nanosecond buckets, since UUIDs in the same bucket sort by their random bits.
+func newV7InWindow(r *rand.Rand, start time.Time, end time.Time, index int64, count int64) (uuid.UUID, error) {
+	if start.UnixNano() < 0 {
+		return uuid.Nil, fmt.Errorf("uuidv7 time window cannot start before 1970: %s", start.Format(time.RFC3339))
+	}
+	firstBucket := v7BucketCeil(start.UnixNano())
+	buckets := v7BucketCeil(end.UnixNano()) - firstBucket
+	if buckets <= 0 {
+		return uuid.Nil, fmt.Errorf("uuidv7 time window is empty: %s to %s", start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano))
+	}
+	if count > 0 {
+		if index < 0 || index >= count {
+			return uuid.Nil, fmt.Errorf("uuidv7 index must be between 0 and %d, got %d", count-1, index)
+		}
+		if buckets < count {
+			return uuid.Nil, fmt.Errorf("uuidv7 time window is too small for %d ordered UUIDs, it can fit at most %d", count, buckets)
+		}
+		buckets = buckets / count
+		firstBucket += index * buckets
+	}
+	id, err := uuid.NewRandomFromReader(r)
+	if err != nil {
+		return id, err
+	}
+	makeV7(id[:], v7BucketStart(firstBucket+r.Int63n(buckets)))
+	return id, nil
+}
+
+// Generates a v7 UUID from valueFunc args in the format: start, end, [index, count]
+func uuidv7InWindow(r *rand.Rand, args []string) (uuid.UUID, error) {
+	if len(args) != 2 && len(args) != 4 {
+		return uuid.Nil, fmt.Errorf("uuidv7 accepts a time window and optionally an index and count, ex: uuidv7(seed, 2024-01-01, 2024-06-01, 3, 10)")
+	}
+	now := time.Now().UTC()
+	start, err := parseTimeBound(args[0], now, time.UTC)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	end, err := parseTimeBound(args[1], now, time.UTC)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	index, count := int64(0), int64(0)
+	if len(args) == 4 {
+		index, err = strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		count, err = strconv.ParseInt(args[3], 10, 64)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if count <= 0 {
+			return uuid.Nil, fmt.Errorf("uuidv7 count must be positive, got %d", count)
+		}
+	}
+	return newV7InWindow(r, start, end, index, count)
+}
+
 // makeV7 fill 48 bits time (uuid[0] - uuid[5]), set version b0111 (uuid[6])
 // uuid[8] already has the right version number (Variant is 10)
 // see function NewV7 and NewV7FromReader